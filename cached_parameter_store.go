@@ -0,0 +1,268 @@
+package awsssm
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheFetchTimeout is the longest a coalesced request of a CachedParameterStore can take
+const defaultCacheFetchTimeout = 30 * time.Second
+
+// CachedParameterStore is a ParameterStore that keeps the results of GetParameter in memory
+// Concurrent requests for a parameter that is not cached are coalesced into a single AWS Parameter Store request,
+// and ErrParameterNotFound is cached too so missing parameters don't hit the AWS throttling limits.
+// The coalesced request isn't cancelled with the context of the caller that started it, each caller only stops waiting on its own context.
+// It is cancelled once all its callers stopped waiting, and it fails after 30 seconds so a hanging request can't block the parameter.
+// Puts, deletes and label changes made through the CachedParameterStore invalidate the affected entries,
+// changes made by anything else are only visible once the entries expire.
+type CachedParameterStore struct {
+	*ParameterStore
+	ttl         time.Duration
+	negativeTTL time.Duration
+	// fetchTimeout bounds the coalesced requests, as they don't keep the deadline of the caller that started them
+	fetchTimeout time.Duration
+	now          func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	calls   map[cacheKey]*cacheCall
+}
+
+type cacheKey struct {
+	name      string
+	decrypted bool
+}
+
+type cacheEntry struct {
+	parameter *Parameter
+	err       error
+	expires   time.Time
+}
+
+type cacheCall struct {
+	done      chan struct{}
+	cancel    context.CancelFunc
+	waiters   int
+	parameter *Parameter
+	err       error
+}
+
+// NewCachedParameterStore is creating a new CachedParameterStore in front of the given ParameterStore
+// Parameters are cached for `ttl` and missing parameters for `negativeTTL`, a `negativeTTL` of 0 disables the negative caching
+func NewCachedParameterStore(store *ParameterStore, ttl, negativeTTL time.Duration) *CachedParameterStore {
+	return &CachedParameterStore{
+		ParameterStore: store,
+		ttl:            ttl,
+		negativeTTL:    negativeTTL,
+		fetchTimeout:   defaultCacheFetchTimeout,
+		now:            time.Now,
+		entries:        make(map[cacheKey]cacheEntry),
+		calls:          make(map[cacheKey]*cacheCall),
+	}
+}
+
+// GetParameter is the same as ParameterStore.GetParameter but returns the cached parameter when it hasn't expired
+func (c *CachedParameterStore) GetParameter(name string, decrypted bool) (*Parameter, error) {
+	return c.GetParameterWithContext(context.Background(), name, decrypted)
+}
+
+// GetParameterWithContext is the same as GetParameter with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (c *CachedParameterStore) GetParameterWithContext(ctx context.Context, name string, decrypted bool) (*Parameter, error) {
+	if name == "" {
+		return nil, ErrParameterInvalidName
+	}
+	key := cacheKey{name: name, decrypted: decrypted}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		if c.now().Before(entry.expires) {
+			c.mu.Unlock()
+			return copyParameter(entry.parameter), entry.err
+		}
+		delete(c.entries, key)
+	}
+	call, ok := c.calls[key]
+	if !ok {
+		// the request is shared with the callers coalesced into it, so it must not be cancelled with this caller's context
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.fetchTimeout)
+		call = &cacheCall{done: make(chan struct{}), cancel: cancel}
+		c.calls[key] = call
+		go c.fetch(fetchCtx, key, call)
+	}
+	call.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return copyParameter(call.parameter), call.err
+	case <-ctx.Done():
+		c.leave(key, call)
+		return nil, ctx.Err()
+	}
+}
+
+// leave removes a caller that stopped waiting for the call, and cancels the call once nobody waits for it anymore
+func (c *CachedParameterStore) leave(key cacheKey, call *cacheCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	call.cancel()
+}
+
+func (c *CachedParameterStore) fetch(ctx context.Context, key cacheKey, call *cacheCall) {
+	call.parameter, call.err = c.ParameterStore.GetParameterWithContext(ctx, key.name, key.decrypted)
+	call.cancel()
+
+	c.mu.Lock()
+	// the entry was invalidated while the request was in flight, so the result may already be stale
+	if c.calls[key] == call {
+		delete(c.calls, key)
+		switch {
+		case call.err == nil:
+			c.entries[key] = cacheEntry{parameter: call.parameter, expires: c.now().Add(c.ttl)}
+		case call.err == ErrParameterNotFound && c.negativeTTL > 0:
+			c.entries[key] = cacheEntry{err: call.err, expires: c.now().Add(c.negativeTTL)}
+		}
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// Invalidate removes the cached entries of the parameters with the given names, including their versions and labels
+func (c *CachedParameterStore) Invalidate(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, name := range names {
+		for key := range c.entries {
+			if isCachedName(key.name, name) {
+				delete(c.entries, key)
+			}
+		}
+		for key := range c.calls {
+			if isCachedName(key.name, name) {
+				delete(c.calls, key)
+			}
+		}
+	}
+}
+
+// isCachedName reports if the cached name is the parameter name or one of its name:selector variants
+func isCachedName(cached, name string) bool {
+	return cached == name || strings.HasPrefix(cached, name+":")
+}
+
+func copyParameter(p *Parameter) *Parameter {
+	if p == nil {
+		return nil
+	}
+	parameter := *p
+	return &parameter
+}
+
+// PutSecureParameter is the same as ParameterStore.PutSecureParameter and invalidates the cached parameter
+func (c *CachedParameterStore) PutSecureParameter(name, value string, overwrite bool) error {
+	return c.PutSecureParameterWithContext(context.Background(), name, value, overwrite)
+}
+
+// PutSecureParameterWithContext is the same as ParameterStore.PutSecureParameterWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) PutSecureParameterWithContext(ctx context.Context, name, value string, overwrite bool) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.PutSecureParameterWithContext(ctx, name, value, overwrite)
+}
+
+// PutSecureParameterWithCMK is the same as ParameterStore.PutSecureParameterWithCMK and invalidates the cached parameter
+func (c *CachedParameterStore) PutSecureParameterWithCMK(name, value string, overwrite bool, kmsID string) error {
+	return c.PutSecureParameterWithCMKWithContext(context.Background(), name, value, overwrite, kmsID)
+}
+
+// PutSecureParameterWithCMKWithContext is the same as ParameterStore.PutSecureParameterWithCMKWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) PutSecureParameterWithCMKWithContext(ctx context.Context, name, value string, overwrite bool, kmsID string) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.PutSecureParameterWithCMKWithContext(ctx, name, value, overwrite, kmsID)
+}
+
+// PutParameter is the same as ParameterStore.PutParameter and invalidates the cached parameter
+func (c *CachedParameterStore) PutParameter(name, value string, overwrite bool) error {
+	return c.PutParameterWithContext(context.Background(), name, value, overwrite)
+}
+
+// PutParameterWithContext is the same as ParameterStore.PutParameterWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) PutParameterWithContext(ctx context.Context, name, value string, overwrite bool) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.PutParameterWithContext(ctx, name, value, overwrite)
+}
+
+// PutStringListParameter is the same as ParameterStore.PutStringListParameter and invalidates the cached parameter
+func (c *CachedParameterStore) PutStringListParameter(name string, values []string, overwrite bool) error {
+	return c.PutStringListParameterWithContext(context.Background(), name, values, overwrite)
+}
+
+// PutStringListParameterWithContext is the same as ParameterStore.PutStringListParameterWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) PutStringListParameterWithContext(ctx context.Context, name string, values []string, overwrite bool) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.PutStringListParameterWithContext(ctx, name, values, overwrite)
+}
+
+// PutParameterWithOptions is the same as ParameterStore.PutParameterWithOptions and invalidates the cached parameter
+func (c *CachedParameterStore) PutParameterWithOptions(name, value string, options PutParameterOptions) error {
+	return c.PutParameterWithOptionsWithContext(context.Background(), name, value, options)
+}
+
+// PutParameterWithOptionsWithContext is the same as ParameterStore.PutParameterWithOptionsWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) PutParameterWithOptionsWithContext(ctx context.Context, name, value string, options PutParameterOptions) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.PutParameterWithOptionsWithContext(ctx, name, value, options)
+}
+
+// DeleteParameter is the same as ParameterStore.DeleteParameter and invalidates the cached parameter
+func (c *CachedParameterStore) DeleteParameter(name string) error {
+	return c.DeleteParameterWithContext(context.Background(), name)
+}
+
+// DeleteParameterWithContext is the same as ParameterStore.DeleteParameterWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) DeleteParameterWithContext(ctx context.Context, name string) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.DeleteParameterWithContext(ctx, name)
+}
+
+// DeleteParameters is the same as ParameterStore.DeleteParameters and invalidates the cached parameters
+func (c *CachedParameterStore) DeleteParameters(names []string) ([]string, error) {
+	return c.DeleteParametersWithContext(context.Background(), names)
+}
+
+// DeleteParametersWithContext is the same as ParameterStore.DeleteParametersWithContext and invalidates the cached parameters
+func (c *CachedParameterStore) DeleteParametersWithContext(ctx context.Context, names []string) ([]string, error) {
+	defer c.Invalidate(names...)
+	return c.ParameterStore.DeleteParametersWithContext(ctx, names)
+}
+
+// LabelParameterVersion is the same as ParameterStore.LabelParameterVersion and invalidates the cached parameter
+func (c *CachedParameterStore) LabelParameterVersion(name string, version int64, labels []string) error {
+	return c.LabelParameterVersionWithContext(context.Background(), name, version, labels)
+}
+
+// LabelParameterVersionWithContext is the same as ParameterStore.LabelParameterVersionWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) LabelParameterVersionWithContext(ctx context.Context, name string, version int64, labels []string) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.LabelParameterVersionWithContext(ctx, name, version, labels)
+}
+
+// UnlabelParameterVersion is the same as ParameterStore.UnlabelParameterVersion and invalidates the cached parameter
+func (c *CachedParameterStore) UnlabelParameterVersion(name string, version int64, labels []string) error {
+	return c.UnlabelParameterVersionWithContext(context.Background(), name, version, labels)
+}
+
+// UnlabelParameterVersionWithContext is the same as ParameterStore.UnlabelParameterVersionWithContext and invalidates the cached parameter
+func (c *CachedParameterStore) UnlabelParameterVersionWithContext(ctx context.Context, name string, version int64, labels []string) error {
+	defer c.Invalidate(name)
+	return c.ParameterStore.UnlabelParameterVersionWithContext(ctx, name, version, labels)
+}
@@ -0,0 +1,295 @@
+package awsssm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// countingSSMClient counts the GetParameter requests and blocks them until release is closed
+type countingSSMClient struct {
+	stubSSMClient
+	calls   int32
+	release chan struct{}
+}
+
+func (s *countingSSMClient) GetParameterWithContext(ctx aws.Context, input *ssm.GetParameterInput, opts ...request.Option) (*ssm.GetParameterOutput, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if s.release != nil {
+		<-s.release
+	}
+	return s.GetParameterOutput, s.GetParameterError
+}
+
+// hangingSSMClient never answers the GetParameter requests, they only end when their context is done
+type hangingSSMClient struct {
+	stubSSMClient
+	calls int32
+}
+
+func (s *hangingSSMClient) GetParameterWithContext(ctx aws.Context, input *ssm.GetParameterInput, opts ...request.Option) (*ssm.GetParameterOutput, error) {
+	atomic.AddInt32(&s.calls, 1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func waitForWaiters(cache *CachedParameterStore, key cacheKey, waiters int) {
+	for {
+		cache.mu.Lock()
+		call, ok := cache.calls[key]
+		joined := ok && call.waiters == waiters
+		cache.mu.Unlock()
+		if joined {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newTestCachedParameterStore(client ssmClient, now *time.Time) *CachedParameterStore {
+	cache := NewCachedParameterStore(NewParameterStoreWithClient(client), time.Minute, 10*time.Second)
+	cache.now = func() time.Time { return *now }
+	return cache
+}
+
+func TestCachedParameterStore_GetParameter(t *testing.T) {
+	now := time.Now()
+	client := &countingSSMClient{stubSSMClient: stubSSMClient{
+		GetParameterOutput: &ssm.GetParameterOutput{Parameter: param1},
+	}}
+	cache := newTestCachedParameterStore(client, &now)
+
+	for i := 0; i < 3; i++ {
+		parameter, err := cache.GetParameter("/my-service/dev/DB_PASSWORD", true)
+		if err != nil {
+			t.Fatalf(`Unexpected error: %s`, err)
+		}
+		if parameter.GetValue() != "something-secure" {
+			t.Errorf(`Unexpected value: got %s, expected %s`, parameter.GetValue(), "something-secure")
+		}
+	}
+	if calls := atomic.LoadInt32(&client.calls); calls != 1 {
+		t.Errorf(`Unexpected requests: got %d, expected %d`, calls, 1)
+	}
+
+	if _, err := cache.GetParameter("/my-service/dev/DB_PASSWORD", false); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if calls := atomic.LoadInt32(&client.calls); calls != 2 {
+		t.Errorf(`Unexpected requests for a different decryption: got %d, expected %d`, calls, 2)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := cache.GetParameter("/my-service/dev/DB_PASSWORD", true); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if calls := atomic.LoadInt32(&client.calls); calls != 3 {
+		t.Errorf(`Unexpected requests after expiration: got %d, expected %d`, calls, 3)
+	}
+}
+
+func TestCachedParameterStore_GetParameterNotFound(t *testing.T) {
+	now := time.Now()
+	client := &countingSSMClient{stubSSMClient: stubSSMClient{
+		GetParameterError: awserr.New(ssm.ErrCodeParameterNotFound, "parameter not found", nil),
+	}}
+	cache := newTestCachedParameterStore(client, &now)
+
+	for i := 0; i < 3; i++ {
+		if _, err := cache.GetParameter("/my-service/dev/NOT_FOUND", true); err != ErrParameterNotFound {
+			t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterNotFound)
+		}
+	}
+	if calls := atomic.LoadInt32(&client.calls); calls != 1 {
+		t.Errorf(`Unexpected requests: got %d, expected %d`, calls, 1)
+	}
+
+	now = now.Add(11 * time.Second)
+	if _, err := cache.GetParameter("/my-service/dev/NOT_FOUND", true); err != ErrParameterNotFound {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterNotFound)
+	}
+	if calls := atomic.LoadInt32(&client.calls); calls != 2 {
+		t.Errorf(`Unexpected requests after expiration: got %d, expected %d`, calls, 2)
+	}
+}
+
+func TestCachedParameterStore_GetParameterCoalesced(t *testing.T) {
+	now := time.Now()
+	client := &countingSSMClient{
+		stubSSMClient: stubSSMClient{GetParameterOutput: &ssm.GetParameterOutput{Parameter: param1}},
+		release:       make(chan struct{}),
+	}
+	cache := newTestCachedParameterStore(client, &now)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parameter, err := cache.GetParameter("/my-service/dev/DB_PASSWORD", true)
+			if err != nil || parameter.GetValue() != "something-secure" {
+				t.Errorf(`Unexpected result: %v, %v`, parameter, err)
+			}
+		}()
+	}
+	for {
+		cache.mu.Lock()
+		inFlight := len(cache.calls)
+		cache.mu.Unlock()
+		if inFlight == 1 && atomic.LoadInt32(&client.calls) == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(client.release)
+	wg.Wait()
+
+	if calls := atomic.LoadInt32(&client.calls); calls != 1 {
+		t.Errorf(`Unexpected requests: got %d, expected %d`, calls, 1)
+	}
+}
+
+func TestCachedParameterStore_GetParameterLeaderCancelled(t *testing.T) {
+	now := time.Now()
+	client := &countingSSMClient{
+		stubSSMClient: stubSSMClient{GetParameterOutput: &ssm.GetParameterOutput{Parameter: param1}},
+		release:       make(chan struct{}),
+	}
+	cache := newTestCachedParameterStore(client, &now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := cache.GetParameterWithContext(ctx, "/my-service/dev/DB_PASSWORD", true)
+		leaderErr <- err
+	}()
+	for atomic.LoadInt32(&client.calls) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	type result struct {
+		parameter *Parameter
+		err       error
+	}
+	follower := make(chan result)
+	go func() {
+		parameter, err := cache.GetParameterWithContext(context.Background(), "/my-service/dev/DB_PASSWORD", true)
+		follower <- result{parameter, err}
+	}()
+	waitForWaiters(cache, cacheKey{name: "/my-service/dev/DB_PASSWORD", decrypted: true}, 2)
+
+	cancel()
+	if err := <-leaderErr; err != context.Canceled {
+		t.Errorf(`Unexpected leader error: got %v, expected %v`, err, context.Canceled)
+	}
+	close(client.release)
+	got := <-follower
+	if got.err != nil || got.parameter.GetValue() != "something-secure" {
+		t.Errorf(`Unexpected follower result: %v, %v`, got.parameter, got.err)
+	}
+	if calls := atomic.LoadInt32(&client.calls); calls != 1 {
+		t.Errorf(`Unexpected requests: got %d, expected %d`, calls, 1)
+	}
+}
+
+func TestCachedParameterStore_GetParameterHanging(t *testing.T) {
+	now := time.Now()
+	client := &hangingSSMClient{}
+	cache := newTestCachedParameterStore(client, &now)
+	cache.fetchTimeout = 10 * time.Millisecond
+
+	for i := 1; i <= 2; i++ {
+		if _, err := cache.GetParameter("/my-service/dev/DB_PASSWORD", true); err != context.DeadlineExceeded {
+			t.Errorf(`Unexpected error: got %v, expected %v`, err, context.DeadlineExceeded)
+		}
+		if calls := atomic.LoadInt32(&client.calls); calls != int32(i) {
+			t.Errorf(`Unexpected requests: got %d, expected %d`, calls, i)
+		}
+	}
+	cache.mu.Lock()
+	entries, calls := len(cache.entries), len(cache.calls)
+	cache.mu.Unlock()
+	if entries != 0 || calls != 0 {
+		t.Errorf(`Unexpected entries and calls after a timeout: got %d and %d, expected none`, entries, calls)
+	}
+}
+
+func TestCachedParameterStore_GetParameterAllCancelled(t *testing.T) {
+	now := time.Now()
+	client := &hangingSSMClient{}
+	cache := newTestCachedParameterStore(client, &now)
+	key := cacheKey{name: "/my-service/dev/DB_PASSWORD", decrypted: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := cache.GetParameterWithContext(ctx, key.name, key.decrypted)
+			errs <- err
+		}()
+	}
+	waitForWaiters(cache, key, 2)
+	cache.mu.Lock()
+	call := cache.calls[key]
+	cache.mu.Unlock()
+
+	cancel()
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != context.Canceled {
+			t.Errorf(`Unexpected error: got %v, expected %v`, err, context.Canceled)
+		}
+	}
+	select {
+	case <-call.done:
+	case <-time.After(time.Second):
+		t.Fatal(`The request wasn't cancelled once every caller stopped waiting`)
+	}
+	if call.err != context.Canceled {
+		t.Errorf(`Unexpected request error: got %v, expected %v`, call.err, context.Canceled)
+	}
+}
+
+func TestCachedParameterStore_Invalidation(t *testing.T) {
+	now := time.Now()
+	client := &countingSSMClient{stubSSMClient: stubSSMClient{
+		GetParameterOutput: &ssm.GetParameterOutput{Parameter: param1},
+	}}
+	cache := newTestCachedParameterStore(client, &now)
+
+	mutations := []func() error{
+		func() error { return cache.PutParameter("/my-service/dev/DB_PASSWORD", "new", true) },
+		func() error { return cache.PutSecureParameter("/my-service/dev/DB_PASSWORD", "new", true) },
+		func() error {
+			return cache.PutParameterWithOptions("/my-service/dev/DB_PASSWORD", "new", PutParameterOptions{Overwrite: true})
+		},
+		func() error { return cache.DeleteParameter("/my-service/dev/DB_PASSWORD") },
+		func() error {
+			_, err := cache.DeleteParameters([]string{"/my-service/dev/DB_PASSWORD"})
+			return err
+		},
+	}
+	for i, mutate := range mutations {
+		if _, err := cache.GetParameter("/my-service/dev/DB_PASSWORD", true); err != nil {
+			t.Fatalf(`Unexpected error: %s`, err)
+		}
+		if _, err := cache.GetParameter("/my-service/dev/DB_PASSWORD:prod-approved", true); err != nil {
+			t.Fatalf(`Unexpected error: %s`, err)
+		}
+		if err := mutate(); err != nil {
+			t.Fatalf(`Unexpected error: %s`, err)
+		}
+		cache.mu.Lock()
+		entries := len(cache.entries)
+		cache.mu.Unlock()
+		if entries != 0 {
+			t.Errorf(`Unexpected cached entries after mutation %d: got %d, expected %d`, i, entries, 0)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package awsssm
+
+import (
+	"strings"
+)
+
+// LayeredParameters holds several Parameters overlaid by their names relative to their base paths,
+// for example defaults under /common/, environment overrides under /common/prod/ and service overrides under /svc/prod/
+// The values are accessed by their relative name with GetValueByName, GetAllValues, Decode, etc...
+// and Source returns which layer supplied each of them
+type LayeredParameters struct {
+	*Parameters
+	sources map[string]string
+}
+
+// NewLayeredParameters creates a LayeredParameters from the given layers, ordered from the lowest to the highest precedence
+// so a parameter of a layer overrides the parameter with the same relative name of all the previous layers
+func NewLayeredParameters(layers ...*Parameters) *LayeredParameters {
+	parameters := make(map[string]*Parameter)
+	sources := make(map[string]string)
+	for _, layer := range layers {
+		if layer == nil {
+			continue
+		}
+		for fullPath, parameter := range layer.parameters {
+			name := strings.Replace(fullPath, layer.basePath, "", 1)
+			parameters[name] = parameter
+			sources[name] = fullPath
+		}
+	}
+	layered := &LayeredParameters{
+		Parameters: NewParameters("", parameters),
+		sources:    sources,
+	}
+	layered.relativeKeys = true
+	return layered
+}
+
+// Source returns the full path of the parameter that supplied the value of the given relative name
+func (l *LayeredParameters) Source(name string) (string, bool) {
+	source, ok := l.sources[name]
+	return source, ok
+}
+
+// Sources returns the full paths of the parameters that supplied the values, by relative name
+func (l *LayeredParameters) Sources() map[string]string {
+	sources := make(map[string]string, len(l.sources))
+	for name, source := range l.sources {
+		sources[name] = source
+	}
+	return sources
+}
@@ -0,0 +1,75 @@
+package awsssm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestLayeredParameters(t *testing.T) {
+	common := NewParameters("/common/", map[string]*Parameter{
+		"/common/DB_HOST":   {Value: aws.String("localhost")},
+		"/common/DB_PORT":   {Value: aws.String("5432")},
+		"/common/LOG_LEVEL": {Value: aws.String("debug")},
+	})
+	commonProd := NewParameters("/common/prod/", map[string]*Parameter{
+		"/common/prod/DB_HOST":   {Value: aws.String("rds.something.aws.com")},
+		"/common/prod/LOG_LEVEL": {Value: aws.String("info")},
+	})
+	svcProd := NewParameters("/svc/prod/", map[string]*Parameter{
+		"/svc/prod/LOG_LEVEL": {Value: aws.String("warn")},
+	})
+
+	layered := NewLayeredParameters(common, commonProd, nil, svcProd)
+
+	expectedValues := map[string]string{
+		"DB_HOST":   "rds.something.aws.com",
+		"DB_PORT":   "5432",
+		"LOG_LEVEL": "warn",
+	}
+	if values := layered.GetAllValues(); !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf(`Unexpected values: got %v, expected %v`, values, expectedValues)
+	}
+	if value := layered.GetValueByName("DB_HOST"); value != "rds.something.aws.com" {
+		t.Errorf(`Unexpected value: got %s, expected %s`, value, "rds.something.aws.com")
+	}
+
+	expectedSources := map[string]string{
+		"DB_HOST":   "/common/prod/DB_HOST",
+		"DB_PORT":   "/common/DB_PORT",
+		"LOG_LEVEL": "/svc/prod/LOG_LEVEL",
+	}
+	if sources := layered.Sources(); !reflect.DeepEqual(sources, expectedSources) {
+		t.Errorf(`Unexpected sources: got %v, expected %v`, sources, expectedSources)
+	}
+	if source, ok := layered.Source("DB_PORT"); !ok || source != "/common/DB_PORT" {
+		t.Errorf(`Unexpected source: got %s, expected %s`, source, "/common/DB_PORT")
+	}
+	if _, ok := layered.Source("NOT_EXISTING_PARAMETER"); ok {
+		t.Error(`Unexpected source for a parameter that doesn't exist`)
+	}
+
+	nested := NewLayeredParameters(NewParameters("/common/", map[string]*Parameter{
+		"/common/db/host": {Value: aws.String("localhost")},
+	}))
+	var nestedConfig struct {
+		DB struct {
+			Host string
+		}
+	}
+	if err := nested.Decode(&nestedConfig); err != nil || nestedConfig.DB.Host != "localhost" {
+		t.Errorf(`Unexpected nested config: %+v, %v`, nestedConfig, err)
+	}
+
+	var config struct {
+		DatabaseHost string `mapstructure:"DB_HOST"`
+		DatabasePort int    `mapstructure:"DB_PORT"`
+	}
+	if err := layered.Decode(&config); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if config.DatabaseHost != "rds.something.aws.com" || config.DatabasePort != 5432 {
+		t.Errorf(`Unexpected config: %+v`, config)
+	}
+}
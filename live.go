@@ -0,0 +1,56 @@
+package awsssm
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Live holds the parameters of a watched path decoded into a T, and decodes them again every time they change
+// The decoded value is replaced atomically, so readers always see a complete value.
+// When decoding fails the last good value is kept and the error is passed to the error handler
+type Live[T any] struct {
+	value   atomic.Pointer[T]
+	onError func(error)
+
+	// mu serialises the decodes, refresh is the number of the refresh of the last decoded parameters
+	mu      sync.Mutex
+	refresh uint64
+}
+
+// NewLive is creating a new Live subscribed to the changes of the given Watcher
+// The parameters are decoded with Parameters.Decode, onError is called with the decoding errors and can be nil
+func NewLive[T any](watcher *Watcher, onError func(error)) *Live[T] {
+	live := &Live[T]{onError: onError}
+	parameters, refresh := watcher.subscribeWithSnapshot(func(change ParametersChange) {
+		live.decode(change.Parameters, change.refresh)
+	})
+	if parameters != nil {
+		live.decode(parameters, refresh)
+	}
+	return live
+}
+
+// Load returns the last successfully decoded value, or nil if the parameters were never decoded
+// The returned value is shared with the other readers and must not be modified
+func (l *Live[T]) Load() *T {
+	return l.value.Load()
+}
+
+// decode decodes the parameters unless more recent ones were already decoded, as the current parameters
+// of the Watcher can be decoded at the same time as a change
+func (l *Live[T]) decode(parameters *Parameters, refresh uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if refresh < l.refresh {
+		return
+	}
+	l.refresh = refresh
+	value := new(T)
+	if err := parameters.Decode(value); err != nil {
+		if l.onError != nil {
+			l.onError(err)
+		}
+		return
+	}
+	l.value.Store(value)
+}
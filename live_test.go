@@ -0,0 +1,144 @@
+package awsssm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+type liveEnv struct {
+	DatabaseHost string `mapstructure:"DB_HOST"`
+	Port         int    `mapstructure:"PORT"`
+}
+
+func TestLive(t *testing.T) {
+	port := new(ssm.Parameter).SetName("/my-service/dev/PORT").SetValue("5432")
+	invalidPort := new(ssm.Parameter).SetName("/my-service/dev/PORT").SetValue("not-a-port")
+	changedHost := new(ssm.Parameter).SetName("/my-service/dev/DB_HOST").SetValue("rds.something-else.aws.com")
+
+	stub := &stubSSMClient{GetParametersByPathOutput: stubPathOutput(param2, port)}
+	watcher := NewWatcher(NewParameterStoreWithClient(stub), "/my-service/dev/", WatcherOptions{})
+	var errs []error
+	live := NewLive[liveEnv](watcher, func(err error) {
+		errs = append(errs, err)
+	})
+	if live.Load() != nil {
+		t.Errorf(`Unexpected value before the first refresh: %+v`, live.Load())
+	}
+
+	refreshes := []struct {
+		name           string
+		output         []stubGetParametersByPathOutput
+		expectedValue  liveEnv
+		expectedErrors int
+	}{
+		{
+			name:          "Initial Refresh",
+			output:        stubPathOutput(param2, port),
+			expectedValue: liveEnv{DatabaseHost: "rds.something.aws.com", Port: 5432},
+		},
+		{
+			name:          "Changed Value",
+			output:        stubPathOutput(changedHost, port),
+			expectedValue: liveEnv{DatabaseHost: "rds.something-else.aws.com", Port: 5432},
+		},
+		{
+			name:           "Decode Error Keeps Last Good Value",
+			output:         stubPathOutput(param2, invalidPort),
+			expectedValue:  liveEnv{DatabaseHost: "rds.something-else.aws.com", Port: 5432},
+			expectedErrors: 1,
+		},
+	}
+	for _, refresh := range refreshes {
+		t.Run(refresh.name, func(t *testing.T) {
+			errs = nil
+			stub.GetParametersByPathOutput = refresh.output
+			if err := watcher.Refresh(context.Background()); err != nil {
+				t.Fatalf(`Unexpected error: %s`, err)
+			}
+			if value := live.Load(); value == nil || *value != refresh.expectedValue {
+				t.Errorf(`Unexpected value: got %+v, expected %+v`, value, refresh.expectedValue)
+			}
+			if len(errs) != refresh.expectedErrors {
+				t.Errorf(`Unexpected errors: got %v, expected %d`, errs, refresh.expectedErrors)
+			}
+		})
+	}
+
+	stub.GetParametersByPathOutput = stubPathOutput(param2, port)
+	if err := watcher.Refresh(context.Background()); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if value := NewLive[liveEnv](watcher, nil).Load(); value == nil {
+		t.Error(`Expected a Live created after a refresh to decode the current parameters`)
+	}
+}
+
+func TestLive_ConcurrentRefresh(t *testing.T) {
+	port := new(ssm.Parameter).SetName("/my-service/dev/PORT").SetValue("5432")
+	changedHost := new(ssm.Parameter).SetName("/my-service/dev/DB_HOST").SetValue("rds.something-else.aws.com")
+	outputs := [][]stubGetParametersByPathOutput{stubPathOutput(param2, port), stubPathOutput(changedHost, port)}
+
+	stub := &stubSSMClient{}
+	watcher := NewWatcher(NewParameterStoreWithClient(stub), "/my-service/dev/", WatcherOptions{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			stub.GetParametersByPathOutput = outputs[i%len(outputs)]
+			if err := watcher.Refresh(context.Background()); err != nil {
+				t.Errorf(`Unexpected error: %s`, err)
+				return
+			}
+		}
+	}()
+	var lives []*Live[liveEnv]
+	for i := 0; i < 100; i++ {
+		lives = append(lives, NewLive[liveEnv](watcher, nil))
+	}
+	<-done
+
+	var expected liveEnv
+	if err := watcher.Parameters().Decode(&expected); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	for i, live := range lives {
+		if value := live.Load(); value == nil || *value != expected {
+			t.Errorf(`Unexpected value of Live %d: got %+v, expected %+v`, i, value, expected)
+		}
+	}
+}
+
+func TestLive_SnapshotOutsideWatcherLock(t *testing.T) {
+	invalidPort := new(ssm.Parameter).SetName("/my-service/dev/PORT").SetValue("not-a-port")
+	stub := &stubSSMClient{GetParametersByPathOutput: stubPathOutput(param2, invalidPort)}
+	watcher := NewWatcher(NewParameterStoreWithClient(stub), "/my-service/dev/", WatcherOptions{})
+	if err := watcher.Refresh(context.Background()); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewLive[liveEnv](watcher, func(err error) {
+			watcher.Parameters()
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal(`NewLive didn't return when its error handler uses the Watcher`)
+	}
+
+	port := new(ssm.Parameter).SetName("/my-service/dev/PORT").SetValue("5432")
+	live := NewLive[liveEnv](watcher, nil)
+	live.decode(NewParameters("/my-service/dev/", map[string]*Parameter{
+		"/my-service/dev/PORT": newParameter(port),
+	}), 2)
+	live.decode(watcher.Parameters(), 1)
+	if value := live.Load(); value == nil || value.Port != 5432 {
+		t.Errorf(`Unexpected value after decoding older parameters: got %+v, expected the port %d`, value, 5432)
+	}
+}
@@ -0,0 +1,209 @@
+package awsssm
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// loaderField is a struct field that LoadInto fills with a parameter
+type loaderField struct {
+	value     reflect.Value
+	fieldPath string
+	name      string
+	required  bool
+	secret    bool
+	defaults  *string
+}
+
+// LoadInto fetches all the parameters under the path, recursively and decrypted, and sets them to the fields of the given struct
+// based on their `ssm` tags. The tag holds the parameter name relative to the path, or its full path when it starts with a /,
+// followed by the options:
+//   - required: the parameter has to exist, unless the field has a default
+//   - secret: the parameter has to be a SecureString
+//
+// A `default` tag holds the value used when the parameter doesn't exist. Nested structs with an `ssm` tag are loaded from
+// the sub-path of their name, without options or default, and embedded structs are loaded as if their fields were declared
+// in the parent. Nested structs without an `ssm` tag can't hold `ssm` tagged fields. Pointer fields are only set when
+// the parameter or a default exists. The values are parsed the same way as Get does.
+//
+//	type Config struct {
+//		Host     string `ssm:"DB_HOST,required"`
+//		Port     int    `ssm:"DB_PORT" default:"5432"`
+//		Password string `ssm:"DB_PASSWORD,required,secret"`
+//		Region   string `ssm:"/shared/REGION"`
+//	}
+//
+// All the missing required parameters and invalid values are reported together in the returned error
+func (ps *ParameterStore) LoadInto(ctx context.Context, path string, output interface{}) error {
+	v := reflect.ValueOf(output)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("output must be a non-nil pointer to a struct, got %T", output)
+	}
+	fields, err := collectLoaderFields(v.Elem(), v.Elem().Type().String(), "")
+	if err != nil {
+		return err
+	}
+
+	parameters, err := ps.GetAllParametersByPathRecursiveWithContext(ctx, path, true)
+	if err != nil {
+		return err
+	}
+	var fullPaths []string
+	for _, field := range fields {
+		if strings.HasPrefix(field.name, "/") {
+			fullPaths = append(fullPaths, field.name)
+		}
+	}
+	if len(fullPaths) > 0 {
+		others, _, err := ps.GetParametersWithContext(ctx, fullPaths, true)
+		if err != nil {
+			return err
+		}
+		for name, parameter := range others.parameters {
+			parameters.parameters[name] = parameter
+		}
+	}
+
+	var errs []error
+	for _, field := range fields {
+		fullPath := field.name
+		if !strings.HasPrefix(fullPath, "/") {
+			fullPath = strings.TrimSuffix(path, "/") + "/" + field.name
+		}
+		if err := field.load(fullPath, parameters.parameters[fullPath]); err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", field.fieldPath, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f loaderField) load(fullPath string, parameter *Parameter) error {
+	var value string
+	switch {
+	case parameter != nil:
+		if f.secret && parameter.Type != ParameterTypeSecureString {
+			return fmt.Errorf("%s: %w", fullPath, ErrParameterNotSecure)
+		}
+		value = parameter.GetValue()
+	case f.defaults != nil:
+		value = *f.defaults
+	case f.required:
+		return &MissingParameterError{FullPath: fullPath}
+	default:
+		return nil
+	}
+	target := f.value.Addr()
+	if f.value.Kind() == reflect.Ptr {
+		target = reflect.New(f.value.Type().Elem())
+	}
+	if err := parseInto(value, target.Interface()); err != nil {
+		return &ParameterFormatError{Name: fullPath, Type: f.value.Type().String(), Err: err}
+	}
+	if f.value.Kind() == reflect.Ptr {
+		f.value.Set(target)
+	}
+	return nil
+}
+
+func collectLoaderFields(v reflect.Value, fieldPath, prefix string) ([]loaderField, error) {
+	var fields []loaderField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag, ok := structField.Tag.Lookup("ssm")
+		// the exported fields of an embedded struct can be set even when its type isn't exported
+		embedded := structField.Anonymous && structField.Type.Kind() == reflect.Struct
+		if tag == "-" || (!structField.IsExported() && !embedded) {
+			continue
+		}
+		if !ok {
+			nested, err := collectUntaggedLoaderFields(v.Field(i), structField, fieldPath+"."+structField.Name, prefix)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+		options := strings.Split(tag, ",")
+		field := loaderField{
+			value:     v.Field(i),
+			fieldPath: fieldPath + "." + structField.Name,
+			name:      options[0],
+		}
+		if field.name == "" {
+			field.name = structField.Name
+		}
+		if !strings.HasPrefix(field.name, "/") {
+			field.name = prefix + field.name
+		}
+		for _, option := range options[1:] {
+			switch option {
+			case "required":
+				field.required = true
+			case "secret":
+				field.secret = true
+			default:
+				return nil, fmt.Errorf("field %s: unknown ssm tag option %s", field.fieldPath, option)
+			}
+		}
+		if defaults, ok := structField.Tag.Lookup("default"); ok {
+			field.defaults = &defaults
+		}
+
+		if isNestedStruct(field.value) {
+			if len(options) > 1 || field.defaults != nil {
+				return nil, fmt.Errorf("field %s: ssm tag options and default can't apply to a nested struct", field.fieldPath)
+			}
+			nested, err := collectLoaderFields(field.value, field.fieldPath, strings.TrimSuffix(field.name, "/")+"/")
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// collectUntaggedLoaderFields collects the fields of an embedded struct as if they were declared in the parent,
+// and makes sure the other structs without an `ssm` tag don't hold fields that would be silently left empty
+func collectUntaggedLoaderFields(v reflect.Value, structField reflect.StructField, fieldPath, prefix string) ([]loaderField, error) {
+	if structField.Anonymous && v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if !isNestedStruct(v) {
+		return nil, nil
+	}
+	fields, err := collectLoaderFields(v, fieldPath, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if !structField.Anonymous && len(fields) > 0 {
+		return nil, fmt.Errorf("field %s: nested struct with ssm tagged fields needs an ssm tag", fieldPath)
+	}
+	return fields, nil
+}
+
+var (
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// isNestedStruct reports if the field is a struct loaded field by field, rather than parsed from a single value
+func isNestedStruct(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	ptr := reflect.PointerTo(v.Type())
+	return !ptr.Implements(textUnmarshalerType) && !ptr.Implements(binaryUnmarshalerType) && !ptr.Implements(jsonUnmarshalerType)
+}
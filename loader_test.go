@@ -0,0 +1,176 @@
+package awsssm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+type loaderConfig struct {
+	Host     string        `ssm:"DB_HOST,required"`
+	Port     int           `ssm:"DB_PORT" default:"5432"`
+	Password string        `ssm:"DB_PASSWORD,required,secret"`
+	Timeout  time.Duration `ssm:"TIMEOUT" default:"5s"`
+	Region   string        `ssm:"/shared/REGION,required"`
+	Replica  struct {
+		Host string `ssm:"host,required"`
+	} `ssm:"replica"`
+	Ignored string
+}
+
+func TestParameterStore_LoadInto(t *testing.T) {
+	secret := new(ssm.Parameter).
+		SetName("/my-service/dev/DB_PASSWORD").
+		SetValue("something-secure").
+		SetType(ssm.ParameterTypeSecureString)
+	replicaHost := new(ssm.Parameter).
+		SetName("/my-service/dev/replica/host").
+		SetValue("replica.something.aws.com")
+	region := new(ssm.Parameter).
+		SetName("/shared/REGION").
+		SetValue("eu-west-1")
+
+	stub := &stubSSMClient{
+		GetParametersByPathOutput: stubPathOutput(param2, secret, replicaHost),
+		GetParametersValues:       map[string]*ssm.Parameter{"/shared/REGION": region},
+	}
+	var config loaderConfig
+	err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", &config)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expected := loaderConfig{
+		Host:     "rds.something.aws.com",
+		Port:     5432,
+		Password: "something-secure",
+		Timeout:  5 * time.Second,
+		Region:   "eu-west-1",
+	}
+	expected.Replica.Host = "replica.something.aws.com"
+	if config != expected {
+		t.Errorf(`Unexpected config: got %+v, expected %+v`, config, expected)
+	}
+	if !*stub.GetParametersByPathInput.Recursive || !*stub.GetParametersByPathInput.WithDecryption {
+		t.Errorf(`Unexpected request: %v`, stub.GetParametersByPathInput)
+	}
+}
+
+func TestParameterStore_LoadIntoPathWithoutTrailingSlash(t *testing.T) {
+	stub := &stubSSMClient{
+		GetParametersByPathOutput: stubPathOutput(param2),
+	}
+	var config struct {
+		Host string `ssm:"DB_HOST,required"`
+	}
+	err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev", &config)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if config.Host != "rds.something.aws.com" {
+		t.Errorf(`Unexpected value: got %s, expected %s`, config.Host, "rds.something.aws.com")
+	}
+}
+
+type loaderDB struct {
+	Host string `ssm:"DB_HOST,required"`
+}
+
+func TestParameterStore_LoadIntoEmbeddedAndPointers(t *testing.T) {
+	port := new(ssm.Parameter).
+		SetName("/my-service/dev/DB_PORT").
+		SetValue("5432")
+	stub := &stubSSMClient{
+		GetParametersByPathOutput: stubPathOutput(param2, port),
+	}
+	var config struct {
+		loaderDB
+		Port     *int    `ssm:"DB_PORT"`
+		User     *string `ssm:"DB_USERNAME"`
+		Database *string `ssm:"DB_NAME" default:"payments"`
+	}
+	err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", &config)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if config.Host != "rds.something.aws.com" {
+		t.Errorf(`Unexpected value: got %s, expected %s`, config.Host, "rds.something.aws.com")
+	}
+	if config.Port == nil || *config.Port != 5432 {
+		t.Errorf(`Unexpected port: got %v, expected %d`, config.Port, 5432)
+	}
+	if config.User != nil {
+		t.Errorf(`Unexpected user: got %s, expected nil`, *config.User)
+	}
+	if config.Database == nil || *config.Database != "payments" {
+		t.Errorf(`Unexpected database: got %v, expected %s`, config.Database, "payments")
+	}
+
+	stub.GetParametersByPathOutput = stubPathOutput(port)
+	err = NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", &config)
+	expected := "field struct { awsssm.loaderDB; "
+	if err == nil || !strings.Contains(err.Error(), ".loaderDB.Host: parameter not found: /my-service/dev/DB_HOST") {
+		t.Errorf(`Expected the missing embedded field to be reported, got %v`, err)
+	}
+	if err != nil && !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf(`Expected the error to start with the type of the struct %q, got %s`, expected, err)
+	}
+}
+
+func TestParameterStore_LoadIntoErrors(t *testing.T) {
+	plainPassword := new(ssm.Parameter).
+		SetName("/my-service/dev/DB_PASSWORD").
+		SetValue("something-secure").
+		SetType(ssm.ParameterTypeString)
+	invalidPort := new(ssm.Parameter).
+		SetName("/my-service/dev/DB_PORT").
+		SetValue("not-a-port")
+
+	stub := &stubSSMClient{
+		GetParametersByPathOutput: stubPathOutput(plainPassword, invalidPort),
+	}
+	var config loaderConfig
+	err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", &config)
+	if err == nil {
+		t.Fatal(`Expected an error`)
+	}
+	for _, expected := range []string{
+		"field awsssm.loaderConfig.Host: parameter not found: /my-service/dev/DB_HOST",
+		"field awsssm.loaderConfig.Region: parameter not found: /shared/REGION",
+		"field awsssm.loaderConfig.Replica.Host: parameter not found: /my-service/dev/replica/host",
+		"field awsssm.loaderConfig.Password: /my-service/dev/DB_PASSWORD: parameter is not a SecureString",
+		"field awsssm.loaderConfig.Port: invalid format of parameter /my-service/dev/DB_PORT: expected int",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf(`Expected the error to contain %q, got %s`, expected, err)
+		}
+	}
+	if !errors.Is(err, ErrParameterNotFound) || !errors.Is(err, ErrParameterNotSecure) {
+		t.Errorf(`Unexpected error: %s`, err)
+	}
+
+	if err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", config); err == nil {
+		t.Error(`Expected an error for a non pointer output`)
+	}
+	var invalidTag struct {
+		Host string `ssm:"DB_HOST,optional"`
+	}
+	if err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", &invalidTag); err == nil {
+		t.Error(`Expected an error for an unknown tag option`)
+	}
+	var nestedOptions struct {
+		DB loaderDB `ssm:"db,required"`
+	}
+	if err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", &nestedOptions); err == nil {
+		t.Error(`Expected an error for options on a nested struct`)
+	}
+	var untaggedNested struct {
+		DB loaderDB
+	}
+	if err := NewParameterStoreWithClient(stub).LoadInto(context.Background(), "/my-service/dev/", &untaggedNested); err == nil {
+		t.Error(`Expected an error for a nested struct with ssm tagged fields but without an ssm tag`)
+	}
+}
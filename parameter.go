@@ -2,15 +2,47 @@ package awsssm
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/mitchellh/mapstructure"
 )
 
+// Parameter types supported by AWS Parameter Store
+const (
+	ParameterTypeString       = ssm.ParameterTypeString
+	ParameterTypeStringList   = ssm.ParameterTypeStringList
+	ParameterTypeSecureString = ssm.ParameterTypeSecureString
+)
+
 // Parameter holds a Systems Manager parameter from AWS Parameter Store
 type Parameter struct {
-	Value *string
+	Value            *string
+	Name             string
+	Type             string
+	Version          int64
+	LastModifiedDate time.Time
+	ARN              string
+	DataType         string
+	Selector         string
+}
+
+func newParameter(p *ssm.Parameter) *Parameter {
+	return &Parameter{
+		Value:            p.Value,
+		Name:             aws.StringValue(p.Name),
+		Type:             aws.StringValue(p.Type),
+		Version:          aws.Int64Value(p.Version),
+		LastModifiedDate: aws.TimeValue(p.LastModifiedDate),
+		ARN:              aws.StringValue(p.ARN),
+		DataType:         aws.StringValue(p.DataType),
+		Selector:         aws.StringValue(p.Selector),
+	}
 }
 
 // GetValue return the actual Value of the parameter
@@ -21,6 +53,94 @@ func (p *Parameter) GetValue() string {
 	return *p.Value
 }
 
+// ParameterPolicy holds a policy assigned to a parameter
+type ParameterPolicy struct {
+	Text   string
+	Type   string
+	Status string
+}
+
+func newParameterPolicies(policies []*ssm.ParameterInlinePolicy) []ParameterPolicy {
+	if len(policies) == 0 {
+		return nil
+	}
+	result := make([]ParameterPolicy, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, ParameterPolicy{
+			Text:   aws.StringValue(policy.PolicyText),
+			Type:   aws.StringValue(policy.PolicyType),
+			Status: aws.StringValue(policy.PolicyStatus),
+		})
+	}
+	return result
+}
+
+// ParameterHistory holds a single version of a Systems Manager parameter from AWS Parameter Store
+type ParameterHistory struct {
+	Name             string
+	Type             string
+	Value            string
+	Version          int64
+	LastModifiedUser string
+	LastModifiedDate time.Time
+	Labels           []string
+	Description      string
+	Policies         []ParameterPolicy
+	Tier             string
+	KeyID            string
+	AllowedPattern   string
+	DataType         string
+}
+
+func newParameterHistory(h *ssm.ParameterHistory) ParameterHistory {
+	return ParameterHistory{
+		Name:             aws.StringValue(h.Name),
+		Type:             aws.StringValue(h.Type),
+		Value:            aws.StringValue(h.Value),
+		Version:          aws.Int64Value(h.Version),
+		LastModifiedUser: aws.StringValue(h.LastModifiedUser),
+		LastModifiedDate: aws.TimeValue(h.LastModifiedDate),
+		Labels:           aws.StringValueSlice(h.Labels),
+		Description:      aws.StringValue(h.Description),
+		Policies:         newParameterPolicies(h.Policies),
+		Tier:             aws.StringValue(h.Tier),
+		KeyID:            aws.StringValue(h.KeyId),
+		AllowedPattern:   aws.StringValue(h.AllowedPattern),
+		DataType:         aws.StringValue(h.DataType),
+	}
+}
+
+// ParameterMetadata holds the details of a Systems Manager parameter from AWS Parameter Store, without its value
+type ParameterMetadata struct {
+	Name             string
+	Type             string
+	Version          int64
+	LastModifiedUser string
+	LastModifiedDate time.Time
+	Description      string
+	Policies         []ParameterPolicy
+	Tier             string
+	KeyID            string
+	AllowedPattern   string
+	DataType         string
+}
+
+func newParameterMetadata(m *ssm.ParameterMetadata) ParameterMetadata {
+	return ParameterMetadata{
+		Name:             aws.StringValue(m.Name),
+		Type:             aws.StringValue(m.Type),
+		Version:          aws.Int64Value(m.Version),
+		LastModifiedUser: aws.StringValue(m.LastModifiedUser),
+		LastModifiedDate: aws.TimeValue(m.LastModifiedDate),
+		Description:      aws.StringValue(m.Description),
+		Policies:         newParameterPolicies(m.Policies),
+		Tier:             aws.StringValue(m.Tier),
+		KeyID:            aws.StringValue(m.KeyId),
+		AllowedPattern:   aws.StringValue(m.AllowedPattern),
+		DataType:         aws.StringValue(m.DataType),
+	}
+}
+
 // NewParameters creates a Parameters
 func NewParameters(basePath string, parameters map[string]*Parameter) *Parameters {
 	return &Parameters{
@@ -35,6 +155,8 @@ type Parameters struct {
 	bytesJSON  []byte
 	basePath   string
 	parameters map[string]*Parameter
+	// relativeKeys is set when the parameters are keyed by their relative names rather than their full paths
+	relativeKeys bool
 }
 
 // Read implements the io.Reader interface for the key/value pair
@@ -76,11 +198,301 @@ func (p *Parameters) GetValueByFullPath(name string) string {
 	return parameter.GetValue()
 }
 
+// LookupByName returns the value based on the name, like GetValueByName,
+// and reports if the parameter exists so a missing parameter can be told apart from an empty value
+func (p *Parameters) LookupByName(name string) (string, bool) {
+	return p.LookupByFullPath(p.basePath + name)
+}
+
+// LookupByFullPath returns the value based on the full path, like GetValueByFullPath,
+// and reports if the parameter exists so a missing parameter can be told apart from an empty value
+func (p *Parameters) LookupByFullPath(name string) (string, bool) {
+	parameter, ok := p.parameters[name]
+	if !ok {
+		return "", false
+	}
+	return parameter.GetValue(), true
+}
+
+// MustGetByName returns the value based on the name, or a *MissingParameterError if the parameter doesn't exist
+func (p *Parameters) MustGetByName(name string) (string, error) {
+	return p.MustGetByFullPath(p.basePath + name)
+}
+
+// MustGetByFullPath returns the value based on the full path, or a *MissingParameterError if the parameter doesn't exist
+func (p *Parameters) MustGetByFullPath(name string) (string, error) {
+	value, ok := p.LookupByFullPath(name)
+	if !ok {
+		return "", &MissingParameterError{FullPath: name}
+	}
+	return value, nil
+}
+
+// MissingParameterError is returned when a required parameter doesn't exist
+// It matches ErrParameterNotFound with errors.Is
+type MissingParameterError struct {
+	FullPath string
+}
+
+func (e *MissingParameterError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrParameterNotFound, e.FullPath)
+}
+
+// Is reports if the target is ErrParameterNotFound
+func (e *MissingParameterError) Is(target error) bool {
+	return target == ErrParameterNotFound
+}
+
+// ParameterFormatError is returned by the typed getters of Parameters when the value can't be parsed into the requested type
+// The value itself is left out of the error message, as it may be a secret
+type ParameterFormatError struct {
+	Name string
+	Type string
+	Err  error
+}
+
+func (e *ParameterFormatError) Error() string {
+	return fmt.Sprintf("invalid format of parameter %s: expected %s", e.Name, e.Type)
+}
+
+// Unwrap returns the parsing error, which may contain the value
+func (e *ParameterFormatError) Unwrap() error {
+	return e.Err
+}
+
+// GetInt returns the value of the given name parsed as an int, the same way as Get
+// The error is a *MissingParameterError when the parameter doesn't exist, or is a *ParameterFormatError when it isn't an int
+func (p *Parameters) GetInt(name string) (int, error) {
+	return Get[int](p, name)
+}
+
+// GetBool returns the value of the given name parsed as a bool, accepting the values supported by strconv.ParseBool
+// The error is a *MissingParameterError when the parameter doesn't exist, or is a *ParameterFormatError when it isn't a bool
+func (p *Parameters) GetBool(name string) (bool, error) {
+	return Get[bool](p, name)
+}
+
+// GetFloat returns the value of the given name parsed as a float64, the same way as Get
+// The error is a *MissingParameterError when the parameter doesn't exist, or is a *ParameterFormatError when it isn't a float
+func (p *Parameters) GetFloat(name string) (float64, error) {
+	return Get[float64](p, name)
+}
+
+// GetDuration returns the value of the given name parsed with time.ParseDuration, for example 1m30s
+// The error is a *MissingParameterError when the parameter doesn't exist, or is a *ParameterFormatError when it isn't a duration
+func (p *Parameters) GetDuration(name string) (time.Duration, error) {
+	return Get[time.Duration](p, name)
+}
+
+// GetTime returns the value of the given name parsed as an RFC 3339 time, for example 2019-11-04T10:30:00Z
+// The error is a *MissingParameterError when the parameter doesn't exist, or is a *ParameterFormatError when it isn't a time
+func (p *Parameters) GetTime(name string) (time.Time, error) {
+	return Get[time.Time](p, name)
+}
+
+// GetStringList returns the values of the given StringList name, split on commas
+// An empty value returns an empty list. The error is a *MissingParameterError when the parameter doesn't exist
+func (p *Parameters) GetStringList(name string) ([]string, error) {
+	value, err := p.MustGetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return []string{}, nil
+	}
+	return strings.Split(value, ","), nil
+}
+
+// GetJSON decodes the JSON value of the given name into v
+// The error is a *MissingParameterError when the parameter doesn't exist, or is a *ParameterFormatError when it isn't valid JSON for v
+func (p *Parameters) GetJSON(name string, v interface{}) error {
+	value, err := p.MustGetByName(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return &ParameterFormatError{Name: p.basePath + name, Type: "JSON", Err: err}
+	}
+	return nil
+}
+
 // Decode decodes the parameters into the given struct
 // We are using this package to decode the values to the struct https://github.com/mitchellh/mapstructure
+// Nested paths are decoded into nested structs, so /my-service/dev/db/host is decoded into the Host field of the DB field.
+// Values are weakly typed, so numeric, boolean and duration strings can be decoded into int, bool and time.Duration fields
 // For more details how you can use this check the parameter_test.go file
 func (p *Parameters) Decode(output interface{}) error {
-	return mapstructure.Decode(p.getKeyValueMap(), output)
+	return p.decode(output, nil)
+}
+
+// StrictDecodeError is returned by DecodeStrict when parameters and fields don't match
+// Unused holds the full paths of the parameters without a field,
+// and Unset the fields without a parameter, named with the dotted paths of mapstructure
+type StrictDecodeError struct {
+	Unused []string
+	Unset  []string
+}
+
+func (e *StrictDecodeError) Error() string {
+	var problems []string
+	if len(e.Unused) > 0 {
+		problems = append(problems, "unused parameters: "+strings.Join(e.Unused, ", "))
+	}
+	if len(e.Unset) > 0 {
+		problems = append(problems, "unset fields: "+strings.Join(e.Unset, ", "))
+	}
+	return "strict decoding failed, " + strings.Join(problems, "; ")
+}
+
+// DecodeStrict is the same as Decode but returns a *StrictDecodeError when any parameter isn't decoded into a field,
+// or any field isn't set by a parameter, so typos in parameter names are caught early
+func (p *Parameters) DecodeStrict(output interface{}) error {
+	metadata := &mapstructure.Metadata{}
+	if err := p.decode(output, metadata); err != nil {
+		return err
+	}
+	if len(metadata.Unused) == 0 && len(metadata.Unset) == 0 {
+		return nil
+	}
+	strictErr := &StrictDecodeError{}
+	if len(metadata.Unused) > 0 {
+		hierarchy, err := p.getHierarchicalMap()
+		if err != nil {
+			return err
+		}
+		for _, unused := range metadata.Unused {
+			strictErr.Unused = append(strictErr.Unused, p.getUnusedFullPaths(hierarchy, unused)...)
+		}
+		sort.Strings(strictErr.Unused)
+	}
+	if len(metadata.Unset) > 0 {
+		strictErr.Unset = metadata.Unset
+		sort.Strings(strictErr.Unset)
+	}
+	return strictErr
+}
+
+func (p *Parameters) decode(output interface{}, metadata *mapstructure.Metadata) error {
+	input, err := p.getHierarchicalMap()
+	if err != nil {
+		return err
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Metadata:         metadata,
+		Result:           output,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+// getHierarchicalMap splits the relative parameter names by their path segments
+// so db/host and db/port end up as host and port of the db map.
+// Names that aren't relative to a base path, like the ones of GetParameters, are kept flat
+func (p *Parameters) getHierarchicalMap() (map[string]interface{}, error) {
+	hierarchy := make(map[string]interface{})
+	for fullPath, parameter := range p.parameters {
+		keys := p.getHierarchicalKeys(fullPath)
+		if len(keys) == 0 {
+			continue
+		}
+		node := hierarchy
+		for i, key := range keys[:len(keys)-1] {
+			switch child := node[key].(type) {
+			case nil:
+				next := make(map[string]interface{})
+				node[key] = next
+				node = next
+			case map[string]interface{}:
+				node = child
+			default:
+				return nil, fmt.Errorf("parameter %s conflicts with the value of %s", fullPath, p.basePath+strings.Join(keys[:i+1], "/"))
+			}
+		}
+		leaf := keys[len(keys)-1]
+		if _, ok := node[leaf]; ok {
+			return nil, fmt.Errorf("parameter %s conflicts with a nested path", fullPath)
+		}
+		node[leaf] = parameter.GetValue()
+	}
+	return hierarchy, nil
+}
+
+// getHierarchicalKeys returns the keys of the parameter in the map of getHierarchicalMap, from the root to its value
+func (p *Parameters) getHierarchicalKeys(fullPath string) []string {
+	key := strings.Replace(fullPath, p.basePath, "", 1)
+	if !p.relativeKeys && (p.basePath == "" || !strings.HasPrefix(fullPath, p.basePath)) {
+		return []string{key}
+	}
+	return strings.FieldsFunc(key, func(r rune) bool { return r == '/' })
+}
+
+// getUnusedFullPaths returns the full paths of the parameters under a key that mapstructure reported as unused,
+// the key being the dotted path of the struct fields followed by the keys of getHierarchicalMap that have no field
+func (p *Parameters) getUnusedFullPaths(hierarchy map[string]interface{}, unused string) []string {
+	keys, ok := matchHierarchicalKeys(hierarchy, unused)
+	if !ok {
+		return []string{unused}
+	}
+	var fullPaths []string
+	for fullPath := range p.parameters {
+		if hasKeysPrefix(p.getHierarchicalKeys(fullPath), keys) {
+			fullPaths = append(fullPaths, fullPath)
+		}
+	}
+	sort.Strings(fullPaths)
+	return fullPaths
+}
+
+// matchHierarchicalKeys finds the keys of the map matching the dotted name, case insensitively as mapstructure does
+func matchHierarchicalKeys(node map[string]interface{}, name string) ([]string, bool) {
+	if key, ok := matchKey(node, name); ok {
+		return []string{key}, true
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] != '.' {
+			continue
+		}
+		key, ok := matchKey(node, name[:i])
+		if !ok {
+			continue
+		}
+		child, ok := node[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if keys, ok := matchHierarchicalKeys(child, name[i+1:]); ok {
+			return append([]string{key}, keys...), true
+		}
+	}
+	return nil, false
+}
+
+func matchKey(node map[string]interface{}, name string) (string, bool) {
+	if _, ok := node[name]; ok {
+		return name, true
+	}
+	for key := range node {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func hasKeysPrefix(keys, prefix []string) bool {
+	if len(keys) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if keys[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (p *Parameters) getKeyValueMap() map[string]string {
@@ -0,0 +1,78 @@
+package awsssm
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// ParameterFilter holds a filter used to search parameters with DescribeParameters or GetAllParametersByPathWithFilters
+// Use the Filter* functions to create the filters supported by AWS Parameter Store
+type ParameterFilter struct {
+	Key    string
+	Option string
+	Values []string
+}
+
+// FilterNameBeginsWith matches the parameters with a name that begins with the given prefix
+func FilterNameBeginsWith(prefix string) ParameterFilter {
+	return ParameterFilter{Key: "Name", Option: "BeginsWith", Values: []string{prefix}}
+}
+
+// FilterNameContains matches the parameters with a name that contains the given value
+func FilterNameContains(value string) ParameterFilter {
+	return ParameterFilter{Key: "Name", Option: "Contains", Values: []string{value}}
+}
+
+// FilterType matches the parameters with any of the given types, for example ParameterTypeSecureString
+func FilterType(types ...string) ParameterFilter {
+	return ParameterFilter{Key: "Type", Option: "Equals", Values: types}
+}
+
+// FilterKeyID matches the SecureString parameters encrypted with any of the given KMS keys
+func FilterKeyID(keyIDs ...string) ParameterFilter {
+	return ParameterFilter{Key: "KeyId", Option: "Equals", Values: keyIDs}
+}
+
+// FilterTier matches the parameters with any of the given tiers, for example Standard or Advanced
+func FilterTier(tiers ...string) ParameterFilter {
+	return ParameterFilter{Key: "Tier", Option: "Equals", Values: tiers}
+}
+
+// FilterTag matches the parameters having the tag with the given key
+// When values are given the tag has to have any of them as its value
+func FilterTag(key string, values ...string) ParameterFilter {
+	return ParameterFilter{Key: "tag:" + key, Values: values}
+}
+
+// FilterLabel matches the parameters with a version having any of the given labels
+// It is only supported by GetAllParametersByPathWithFilters, DescribeParameters rejects it
+func FilterLabel(labels ...string) ParameterFilter {
+	return ParameterFilter{Key: "Label", Option: "Equals", Values: labels}
+}
+
+// FilterDataType matches the parameters with any of the given data types, for example text or aws:ec2:image
+func FilterDataType(dataTypes ...string) ParameterFilter {
+	return ParameterFilter{Key: "DataType", Option: "Equals", Values: dataTypes}
+}
+
+// describeFilterKey reports if the filter key is accepted by DescribeParameters, which supports every key but Label
+func describeFilterKey(key string) bool {
+	return key != "Label"
+}
+
+// pathFilterKey reports if the filter key is accepted by GetParametersByPath, which only supports Type, KeyId and Label
+func pathFilterKey(key string) bool {
+	return key == "Type" || key == "KeyId" || key == "Label"
+}
+
+func (f ParameterFilter) toSSM() *ssm.ParameterStringFilter {
+	filter := &ssm.ParameterStringFilter{}
+	filter.SetKey(f.Key)
+	if f.Option != "" {
+		filter.SetOption(f.Option)
+	}
+	if len(f.Values) > 0 {
+		filter.SetValues(aws.StringSlice(f.Values))
+	}
+	return filter
+}
@@ -0,0 +1,71 @@
+package awsssm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+func TestParameterFilter_toSSM(t *testing.T) {
+	tests := []struct {
+		name           string
+		filter         ParameterFilter
+		expectedFilter *ssm.ParameterStringFilter
+	}{
+		{
+			name:   "Name Begins With",
+			filter: FilterNameBeginsWith("/my-service/"),
+			expectedFilter: &ssm.ParameterStringFilter{
+				Key:    aws.String("Name"),
+				Option: aws.String("BeginsWith"),
+				Values: aws.StringSlice([]string{"/my-service/"}),
+			},
+		},
+		{
+			name:   "Type",
+			filter: FilterType(ParameterTypeSecureString, ParameterTypeString),
+			expectedFilter: &ssm.ParameterStringFilter{
+				Key:    aws.String("Type"),
+				Option: aws.String("Equals"),
+				Values: aws.StringSlice([]string{"SecureString", "String"}),
+			},
+		},
+		{
+			name:   "Label",
+			filter: FilterLabel("prod-approved"),
+			expectedFilter: &ssm.ParameterStringFilter{
+				Key:    aws.String("Label"),
+				Option: aws.String("Equals"),
+				Values: aws.StringSlice([]string{"prod-approved"}),
+			},
+		},
+		{
+			name:   "Tag Key Only",
+			filter: FilterTag("owner"),
+			expectedFilter: &ssm.ParameterStringFilter{
+				Key: aws.String("tag:owner"),
+			},
+		},
+		{
+			name:   "Tag With Values",
+			filter: FilterTag("cost-center", "platform"),
+			expectedFilter: &ssm.ParameterStringFilter{
+				Key:    aws.String("tag:cost-center"),
+				Values: aws.StringSlice([]string{"platform"}),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter.toSSM()
+			if !reflect.DeepEqual(filter, test.expectedFilter) {
+				t.Errorf(`Unexpected filter: got %v, expected %v`, filter, test.expectedFilter)
+			}
+			if err := filter.Validate(); err != nil {
+				t.Errorf(`Unexpected validation error: %s`, err)
+			}
+		})
+	}
+}
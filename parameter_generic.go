@@ -0,0 +1,138 @@
+package awsssm
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Get returns the value of the given name, relative to the base path of the parameters, parsed into a T
+// Types implementing encoding.TextUnmarshaler (netip.Addr, time.Time, ...), encoding.BinaryUnmarshaler (url.URL)
+// or json.Unmarshaler are parsed with their own methods, json.Unmarshaler getting the values that aren't a JSON object,
+// array or string as JSON strings. Strings, bools, ints, uints, floats and time.Duration
+// are parsed from their text form, []string from a StringList and any other type from JSON
+// The error is a *MissingParameterError when the parameter doesn't exist, or a *ParameterFormatError when it can't be parsed
+func Get[T any](p *Parameters, name string) (T, error) {
+	var result T
+	value, err := p.MustGetByName(name)
+	if err != nil {
+		return result, err
+	}
+	return parseAs[T](p.basePath+name, value)
+}
+
+// GetAs is the same as ParameterStore.GetParameter but returns the value parsed into a T, the same way as Get
+func GetAs[T any](ps *ParameterStore, name string, decrypted bool) (T, error) {
+	return GetAsWithContext[T](context.Background(), ps, name, decrypted)
+}
+
+// GetAsWithContext is the same as GetAs with the addition of
+// the ability to pass a context for cancellation and deadlines
+func GetAsWithContext[T any](ctx context.Context, ps *ParameterStore, name string, decrypted bool) (T, error) {
+	var result T
+	parameter, err := ps.GetParameterWithContext(ctx, name, decrypted)
+	if err != nil {
+		return result, err
+	}
+	return parseAs[T](name, parameter.GetValue())
+}
+
+func parseAs[T any](name, value string) (T, error) {
+	var result T
+	if err := parseInto(value, &result); err != nil {
+		return result, &ParameterFormatError{Name: name, Type: reflect.TypeOf(&result).Elem().String(), Err: err}
+	}
+	return result, nil
+}
+
+// parseInto parses the value into the target, which has to be a pointer
+func parseInto(value string, target interface{}) error {
+	switch t := target.(type) {
+	case encoding.TextUnmarshaler:
+		return t.UnmarshalText([]byte(value))
+	case encoding.BinaryUnmarshaler:
+		return t.UnmarshalBinary([]byte(value))
+	case json.Unmarshaler:
+		if !isJSONDocument(value) {
+			// plain values, including numbers, booleans and null, are passed as JSON strings
+			// so they can be unmarshalled into string based types
+			quoted, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			return t.UnmarshalJSON(quoted)
+		}
+		return t.UnmarshalJSON([]byte(value))
+	}
+
+	v := reflect.ValueOf(target).Elem()
+	if v.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(duration))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return json.Unmarshal([]byte(value), target)
+		}
+		list := reflect.MakeSlice(v.Type(), 0, 0)
+		if value != "" {
+			for _, item := range strings.Split(value, ",") {
+				list = reflect.Append(list, reflect.ValueOf(item).Convert(v.Type().Elem()))
+			}
+		}
+		v.Set(list)
+	default:
+		return json.Unmarshal([]byte(value), target)
+	}
+	return nil
+}
+
+// isJSONDocument reports if the value is a valid JSON object, array or string
+func isJSONDocument(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || !json.Valid([]byte(trimmed)) {
+		return false
+	}
+	switch trimmed[0] {
+	case '{', '[', '"':
+		return true
+	}
+	return false
+}
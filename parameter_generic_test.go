@@ -0,0 +1,133 @@
+package awsssm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+type environment int
+
+const (
+	environmentDev environment = iota
+	environmentProd
+)
+
+func (e *environment) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "dev":
+		*e = environmentDev
+	case "prod":
+		*e = environmentProd
+	default:
+		return fmt.Errorf("unknown environment %s", text)
+	}
+	return nil
+}
+
+type level string
+
+func (l *level) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*l = level(strings.ToUpper(value))
+	return nil
+}
+
+func TestGet(t *testing.T) {
+	parameters := NewParameters("/my-service/dev/", map[string]*Parameter{
+		"/my-service/dev/PORT":    {Value: aws.String("5432")},
+		"/my-service/dev/DEBUG":   {Value: aws.String("true")},
+		"/my-service/dev/RATIO":   {Value: aws.String("0.25")},
+		"/my-service/dev/TIMEOUT": {Value: aws.String("1m30s")},
+		"/my-service/dev/HOSTS":   {Value: aws.String("a.aws.com,b.aws.com")},
+		"/my-service/dev/URL":     {Value: aws.String("https://rds.something.aws.com:5432/db")},
+		"/my-service/dev/ADDR":    {Value: aws.String("10.0.0.1")},
+		"/my-service/dev/ENV":     {Value: aws.String("prod")},
+		"/my-service/dev/LEVEL":   {Value: aws.String("info")},
+		"/my-service/dev/LIMITS":  {Value: aws.String(`{"max":10}`)},
+		"/my-service/dev/QUOTED":  {Value: aws.String(`"debug"`)},
+	})
+
+	check := func(name string, value, expected interface{}, err error) {
+		t.Helper()
+		if err != nil {
+			t.Errorf(`Unexpected error for %s: %s`, name, err)
+			return
+		}
+		if !reflect.DeepEqual(value, expected) {
+			t.Errorf(`Unexpected value for %s: got %v, expected %v`, name, value, expected)
+		}
+	}
+
+	port, err := Get[uint16](parameters, "PORT")
+	check("PORT", port, uint16(5432), err)
+	debug, err := Get[bool](parameters, "DEBUG")
+	check("DEBUG", debug, true, err)
+	ratio, err := Get[float64](parameters, "RATIO")
+	check("RATIO", ratio, 0.25, err)
+	timeout, err := Get[time.Duration](parameters, "TIMEOUT")
+	check("TIMEOUT", timeout, 90*time.Second, err)
+	hosts, err := Get[[]string](parameters, "HOSTS")
+	check("HOSTS", hosts, []string{"a.aws.com", "b.aws.com"}, err)
+	dbURL, err := Get[url.URL](parameters, "URL")
+	check("URL", dbURL.Host, "rds.something.aws.com:5432", err)
+	addr, err := Get[netip.Addr](parameters, "ADDR")
+	check("ADDR", addr, netip.MustParseAddr("10.0.0.1"), err)
+	env, err := Get[environment](parameters, "ENV")
+	check("ENV", env, environmentProd, err)
+	lvl, err := Get[level](parameters, "LEVEL")
+	check("LEVEL", lvl, level("INFO"), err)
+	limits, err := Get[map[string]int](parameters, "LIMITS")
+	check("LIMITS", limits, map[string]int{"max": 10}, err)
+	quoted, err := Get[level](parameters, "QUOTED")
+	check("QUOTED", quoted, level("DEBUG"), err)
+	for _, value := range []string{"123", "true", "null"} {
+		plain := NewParameters("/my-service/dev/", map[string]*Parameter{
+			"/my-service/dev/LEVEL": {Value: aws.String(value)},
+		})
+		lvl, err := Get[level](plain, "LEVEL")
+		check("LEVEL "+value, lvl, level(strings.ToUpper(value)), err)
+	}
+
+	var missingErr *MissingParameterError
+	if _, err := Get[int](parameters, "NOT_EXISTING_PARAMETER"); !errors.As(err, &missingErr) {
+		t.Errorf(`Unexpected error: got %v, expected a missing parameter error`, err)
+	}
+	var formatErr *ParameterFormatError
+	if _, err := Get[int8](parameters, "PORT"); !errors.As(err, &formatErr) || formatErr.Type != "int8" {
+		t.Errorf(`Unexpected error: got %v, expected a format error`, err)
+	}
+	if _, err := Get[environment](parameters, "LEVEL"); !errors.As(err, &formatErr) {
+		t.Errorf(`Unexpected error: got %v, expected a format error`, err)
+	}
+}
+
+func TestGetAs(t *testing.T) {
+	client := NewParameterStoreWithClient(&stubSSMClient{
+		GetParameterOutput: &ssm.GetParameterOutput{
+			Parameter: new(ssm.Parameter).SetName("/my-service/dev/PORT").SetValue("5432"),
+		},
+	})
+	port, err := GetAs[int](client, "/my-service/dev/PORT", true)
+	if err != nil || port != 5432 {
+		t.Errorf(`Unexpected value: got %d, %v`, port, err)
+	}
+	if _, err := GetAs[bool](client, "/my-service/dev/PORT", true); err == nil {
+		t.Error(`Expected a format error`)
+	}
+	if _, err := GetAs[int](client, "", true); err != ErrParameterInvalidName {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterInvalidName)
+	}
+}
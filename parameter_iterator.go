@@ -0,0 +1,88 @@
+package awsssm
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// maxPathPageSize is the maximum number of parameters AWS Parameter Store returns in a single GetParametersByPath page
+const maxPathPageSize = 10
+
+// PathIteratorOptions holds the settings of a ParameterIterator
+type PathIteratorOptions struct {
+	// Decrypt the SecureString parameters
+	Decrypt bool
+	// Recursive also iterates over the nested paths
+	Recursive bool
+	// PageSize is the number of parameters requested at once, between 1 and 10. 10 is used when empty
+	PageSize int64
+}
+
+// ParameterIterator iterates over the parameters of a path, requesting them from AWS Parameter Store page by page
+// so only a single page is held in memory. Iterate with Next, and check Err once Next returns false:
+//
+//	it := pmstore.IterateParametersByPath(ctx, "/my-service/dev/", awsssm.PathIteratorOptions{})
+//	for it.Next() {
+//		parameter := it.Parameter()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ParameterIterator struct {
+	ctx       context.Context
+	ps        *ParameterStore
+	input     *ssm.GetParametersByPathInput
+	page      []*ssm.Parameter
+	current   *Parameter
+	err       error
+	requested bool
+}
+
+// IterateParametersByPath is returning a ParameterIterator over all the Parameters that are hierarchy linked to this path
+// Stopping the iteration early doesn't request the remaining pages.
+// the `ssm:GetAllParametersByPath` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/*`
+func (ps *ParameterStore) IterateParametersByPath(ctx context.Context, path string, options PathIteratorOptions) *ParameterIterator {
+	if options.PageSize <= 0 || options.PageSize > maxPathPageSize {
+		options.PageSize = maxPathPageSize
+	}
+	input := &ssm.GetParametersByPathInput{}
+	input.SetWithDecryption(options.Decrypt)
+	input.SetPath(path)
+	input.SetRecursive(options.Recursive)
+	input.SetMaxResults(options.PageSize)
+	return &ParameterIterator{ctx: ctx, ps: ps, input: input}
+}
+
+// Next moves to the next parameter, requesting the next page when needed
+// It returns false when there are no more parameters or a request failed
+func (it *ParameterIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || (it.requested && it.input.NextToken == nil) {
+			it.current = nil
+			return false
+		}
+		result, err := it.ps.ssm.GetParametersByPathWithContext(it.ctx, it.input)
+		it.requested = true
+		if err != nil {
+			it.err = err
+			continue
+		}
+		it.page = result.Parameters
+		it.input.NextToken = result.NextToken
+	}
+	it.current = newParameter(it.page[0])
+	it.page = it.page[1:]
+	return true
+}
+
+// Parameter returns the current parameter of the iteration
+func (it *ParameterIterator) Parameter() *Parameter {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ParameterIterator) Err() error {
+	return it.err
+}
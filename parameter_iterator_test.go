@@ -0,0 +1,85 @@
+package awsssm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+func TestParameterIterator(t *testing.T) {
+	pages := []stubGetParametersByPathOutput{
+		{Output: ssm.GetParametersByPathOutput{Parameters: []*ssm.Parameter{param1, param2}}},
+		{Output: ssm.GetParametersByPathOutput{}},
+		{Output: ssm.GetParametersByPathOutput{Parameters: []*ssm.Parameter{param3}}},
+	}
+	tests := []struct {
+		name             string
+		ssmClient        *stubSSMClient
+		options          PathIteratorOptions
+		limit            int
+		expectedNames    []string
+		expectedRequests int
+		expectedPageSize int64
+		expectedError    error
+	}{
+		{
+			name:             "Iterate All Pages",
+			ssmClient:        &stubSSMClient{GetParametersByPathOutput: pages},
+			options:          PathIteratorOptions{PageSize: 2, Recursive: true},
+			expectedNames:    []string{"/my-service/dev/DB_PASSWORD", "/my-service/dev/DB_HOST", "/my-service/dev/DB_USERNAME"},
+			expectedRequests: 3,
+			expectedPageSize: 2,
+		},
+		{
+			name:             "Early Exit",
+			ssmClient:        &stubSSMClient{GetParametersByPathOutput: pages},
+			limit:            1,
+			expectedNames:    []string{"/my-service/dev/DB_PASSWORD"},
+			expectedRequests: 1,
+			expectedPageSize: 10,
+		},
+		{
+			name:             "Page Size Too Large",
+			ssmClient:        &stubSSMClient{GetParametersByPathOutput: pages[:1]},
+			options:          PathIteratorOptions{PageSize: 50},
+			expectedNames:    []string{"/my-service/dev/DB_PASSWORD", "/my-service/dev/DB_HOST"},
+			expectedRequests: 1,
+			expectedPageSize: 10,
+		},
+		{
+			name:             "Failed SSM Request Error",
+			ssmClient:        &stubSSMClient{GetParametersByPathError: errSSM},
+			expectedRequests: 1,
+			expectedPageSize: 10,
+			expectedError:    errSSM,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewParameterStoreWithClient(test.ssmClient)
+			it := client.IterateParametersByPath(context.Background(), "/my-service/dev/", test.options)
+			var names []string
+			for it.Next() {
+				names = append(names, it.Parameter().Name)
+				if len(names) == test.limit {
+					break
+				}
+			}
+			if it.Err() != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, it.Err(), test.expectedError)
+			}
+			if !reflect.DeepEqual(names, test.expectedNames) {
+				t.Errorf(`Unexpected names: got %v, expected %v`, names, test.expectedNames)
+			}
+			if test.ssmClient.GetParametersByPathRequests != test.expectedRequests {
+				t.Errorf(`Unexpected requests: got %d, expected %d`, test.ssmClient.GetParametersByPathRequests, test.expectedRequests)
+			}
+			if pageSize := aws.Int64Value(test.ssmClient.GetParametersByPathInput.MaxResults); pageSize != test.expectedPageSize {
+				t.Errorf(`Unexpected page size: got %d, expected %d`, pageSize, test.expectedPageSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package awsssm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Policy types supported by advanced AWS Parameter Store parameters
+const (
+	PolicyTypeExpiration             = "Expiration"
+	PolicyTypeExpirationNotification = "ExpirationNotification"
+	PolicyTypeNoChangeNotification   = "NoChangeNotification"
+)
+
+// Units of the notification policies intervals
+const (
+	PolicyUnitDays  = "Days"
+	PolicyUnitHours = "Hours"
+)
+
+const (
+	policyVersion         = "1.0"
+	policyTimestampFormat = "2006-01-02T15:04:05.000Z"
+)
+
+// Policy holds a parameter policy as AWS Parameter Store expects it in its JSON format
+// Use ExpirationPolicy, ExpirationNotificationPolicy and NoChangeNotificationPolicy to create one
+type Policy struct {
+	Type       string            `json:"Type"`
+	Version    string            `json:"Version"`
+	Attributes map[string]string `json:"Attributes"`
+}
+
+// ExpirationPolicy deletes the parameter at the given time
+func ExpirationPolicy(at time.Time) Policy {
+	return Policy{
+		Type:       PolicyTypeExpiration,
+		Version:    policyVersion,
+		Attributes: map[string]string{"Timestamp": at.UTC().Format(policyTimestampFormat)},
+	}
+}
+
+// ExpirationNotificationPolicy notifies through Amazon EventBridge the given number of days or hours before the parameter expires
+func ExpirationNotificationPolicy(before int, unit string) Policy {
+	return Policy{
+		Type:       PolicyTypeExpirationNotification,
+		Version:    policyVersion,
+		Attributes: map[string]string{"Before": strconv.Itoa(before), "Unit": unit},
+	}
+}
+
+// NoChangeNotificationPolicy notifies through Amazon EventBridge when the parameter hasn't changed for the given number of days or hours
+func NoChangeNotificationPolicy(after int, unit string) Policy {
+	return Policy{
+		Type:       PolicyTypeNoChangeNotification,
+		Version:    policyVersion,
+		Attributes: map[string]string{"After": strconv.Itoa(after), "Unit": unit},
+	}
+}
+
+// ExpirationTime returns the time an Expiration policy deletes the parameter
+func (p Policy) ExpirationTime() (time.Time, error) {
+	if p.Type != PolicyTypeExpiration {
+		return time.Time{}, fmt.Errorf("policy %s has no expiration time", p.Type)
+	}
+	return time.Parse(time.RFC3339, p.Attributes["Timestamp"])
+}
+
+// NotificationInterval returns how long before the expiration, or after the last change, a notification policy notifies
+func (p Policy) NotificationInterval() (time.Duration, error) {
+	var value string
+	switch p.Type {
+	case PolicyTypeExpirationNotification:
+		value = p.Attributes["Before"]
+	case PolicyTypeNoChangeNotification:
+		value = p.Attributes["After"]
+	default:
+		return 0, fmt.Errorf("policy %s has no notification interval", p.Type)
+	}
+	interval, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval of policy %s: %w", p.Type, err)
+	}
+	switch p.Attributes["Unit"] {
+	case PolicyUnitDays:
+		return time.Duration(interval) * 24 * time.Hour, nil
+	case PolicyUnitHours:
+		return time.Duration(interval) * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid unit of policy %s: %s", p.Type, p.Attributes["Unit"])
+	}
+}
+
+// Parse returns the Policy of the policy text returned by AWS Parameter Store
+func (p ParameterPolicy) Parse() (Policy, error) {
+	var policy Policy
+	if err := json.Unmarshal([]byte(p.Text), &policy); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
+}
+
+func marshalPolicies(policies []Policy) (string, error) {
+	policiesJSON, err := json.Marshal(policies)
+	if err != nil {
+		return "", err
+	}
+	return string(policiesJSON), nil
+}
@@ -0,0 +1,76 @@
+package awsssm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPolicy_Marshal(t *testing.T) {
+	policies, err := marshalPolicies([]Policy{
+		ExpirationPolicy(time.Date(2020, 5, 13, 0, 0, 0, 0, time.UTC)),
+		ExpirationNotificationPolicy(15, PolicyUnitDays),
+		NoChangeNotificationPolicy(20, PolicyUnitHours),
+	})
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expected := `[{"Type":"Expiration","Version":"1.0","Attributes":{"Timestamp":"2020-05-13T00:00:00.000Z"}},` +
+		`{"Type":"ExpirationNotification","Version":"1.0","Attributes":{"Before":"15","Unit":"Days"}},` +
+		`{"Type":"NoChangeNotification","Version":"1.0","Attributes":{"After":"20","Unit":"Hours"}}]`
+	if policies != expected {
+		t.Errorf(`Unexpected policies: got %s, expected %s`, policies, expected)
+	}
+}
+
+func TestParameterPolicy_Parse(t *testing.T) {
+	tests := []struct {
+		name                 string
+		policy               ParameterPolicy
+		expectedPolicy       Policy
+		expectedExpiration   time.Time
+		expectedNotification time.Duration
+	}{
+		{
+			name: "Expiration",
+			policy: ParameterPolicy{
+				Text: `{"Type":"Expiration","Version":"1.0","Attributes":{"Timestamp":"2020-05-13T00:00:00.000Z"}}`,
+			},
+			expectedPolicy:     ExpirationPolicy(time.Date(2020, 5, 13, 0, 0, 0, 0, time.UTC)),
+			expectedExpiration: time.Date(2020, 5, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Expiration Notification",
+			policy: ParameterPolicy{
+				Text: `{"Type":"ExpirationNotification","Version":"1.0","Attributes":{"Before":"15","Unit":"Days"}}`,
+			},
+			expectedPolicy:       ExpirationNotificationPolicy(15, PolicyUnitDays),
+			expectedNotification: 15 * 24 * time.Hour,
+		},
+		{
+			name: "No Change Notification",
+			policy: ParameterPolicy{
+				Text: `{"Type":"NoChangeNotification","Version":"1.0","Attributes":{"After":"20","Unit":"Hours"}}`,
+			},
+			expectedPolicy:       NoChangeNotificationPolicy(20, PolicyUnitHours),
+			expectedNotification: 20 * time.Hour,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy, err := test.policy.Parse()
+			if err != nil {
+				t.Fatalf(`Unexpected error: %s`, err)
+			}
+			if !reflect.DeepEqual(policy, test.expectedPolicy) {
+				t.Errorf(`Unexpected policy: got %+v, expected %+v`, policy, test.expectedPolicy)
+			}
+			if expiration, err := policy.ExpirationTime(); err == nil && !expiration.Equal(test.expectedExpiration) {
+				t.Errorf(`Unexpected expiration: got %s, expected %s`, expiration, test.expectedExpiration)
+			}
+			if interval, err := policy.NotificationInterval(); err == nil && interval != test.expectedNotification {
+				t.Errorf(`Unexpected interval: got %s, expected %s`, interval, test.expectedNotification)
+			}
+		})
+	}
+}
@@ -1,10 +1,17 @@
 package awsssm
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
@@ -14,12 +21,62 @@ var (
 	ErrParameterNotFound = errors.New("parameter not found")
 	//ErrParameterInvalidName error for invalid parameter name
 	ErrParameterInvalidName = errors.New("invalid parameter name")
+	//ErrParameterInvalidValue error for invalid parameter value
+	ErrParameterInvalidValue = errors.New("invalid parameter value")
+	//ErrParameterInvalidSelector error for invalid parameter version or label
+	ErrParameterInvalidSelector = errors.New("invalid parameter selector")
+	//ErrParameterInvalidFilter error for a filter key that isn't supported by the request
+	ErrParameterInvalidFilter = errors.New("invalid parameter filter")
+	//ErrParameterTooLarge error for a parameter value that is larger than the advanced tier limit
+	ErrParameterTooLarge = errors.New("parameter value too large")
+	//ErrParameterNotSecure error for a secret parameter that is not a SecureString
+	ErrParameterNotSecure = errors.New("parameter is not a SecureString")
 )
 
+// Parameter tiers supported by AWS Parameter Store
+const (
+	ParameterTierStandard           = ssm.ParameterTierStandard
+	ParameterTierAdvanced           = ssm.ParameterTierAdvanced
+	ParameterTierIntelligentTiering = ssm.ParameterTierIntelligentTiering
+)
+
+// Maximum sizes in bytes of a parameter value
+const (
+	maxStandardValueSize = 4 * 1024
+	maxAdvancedValueSize = 8 * 1024
+)
+
+// InvalidLabelsError is returned when AWS Parameter Store rejected some of the labels of a parameter version
+type InvalidLabelsError struct {
+	Name   string
+	Labels []string
+}
+
+func (e *InvalidLabelsError) Error() string {
+	return fmt.Sprintf("invalid labels for parameter %s: %s", e.Name, strings.Join(e.Labels, ", "))
+}
+
+// defaultPathsWorkers is the number of paths GetAllParametersByPaths fetches at the same time by default
+const defaultPathsWorkers = 4
+
+// maxNamesPerRequest is the maximum number of names AWS Parameter Store accepts in a single batch request
+const maxNamesPerRequest = 10
+
 type ssmClient interface {
-	GetParametersByPathPages(input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool) error
-	GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
-	PutParameter(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error)
+	GetParametersByPathWithContext(ctx aws.Context, input *ssm.GetParametersByPathInput, opts ...request.Option) (*ssm.GetParametersByPathOutput, error)
+	GetParametersByPathPagesWithContext(ctx aws.Context, input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool, opts ...request.Option) error
+	GetParameterWithContext(ctx aws.Context, input *ssm.GetParameterInput, opts ...request.Option) (*ssm.GetParameterOutput, error)
+	GetParametersWithContext(ctx aws.Context, input *ssm.GetParametersInput, opts ...request.Option) (*ssm.GetParametersOutput, error)
+	DescribeParametersPagesWithContext(ctx aws.Context, input *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool, opts ...request.Option) error
+	GetParameterHistoryPagesWithContext(ctx aws.Context, input *ssm.GetParameterHistoryInput, fn func(*ssm.GetParameterHistoryOutput, bool) bool, opts ...request.Option) error
+	PutParameterWithContext(ctx aws.Context, input *ssm.PutParameterInput, opts ...request.Option) (*ssm.PutParameterOutput, error)
+	DeleteParameterWithContext(ctx aws.Context, input *ssm.DeleteParameterInput, opts ...request.Option) (*ssm.DeleteParameterOutput, error)
+	DeleteParametersWithContext(ctx aws.Context, input *ssm.DeleteParametersInput, opts ...request.Option) (*ssm.DeleteParametersOutput, error)
+	LabelParameterVersionWithContext(ctx aws.Context, input *ssm.LabelParameterVersionInput, opts ...request.Option) (*ssm.LabelParameterVersionOutput, error)
+	AddTagsToResourceWithContext(ctx aws.Context, input *ssm.AddTagsToResourceInput, opts ...request.Option) (*ssm.AddTagsToResourceOutput, error)
+	RemoveTagsFromResourceWithContext(ctx aws.Context, input *ssm.RemoveTagsFromResourceInput, opts ...request.Option) (*ssm.RemoveTagsFromResourceOutput, error)
+	ListTagsForResourceWithContext(ctx aws.Context, input *ssm.ListTagsForResourceInput, opts ...request.Option) (*ssm.ListTagsForResourceOutput, error)
+	UnlabelParameterVersionWithContext(ctx aws.Context, input *ssm.UnlabelParameterVersionInput, opts ...request.Option) (*ssm.UnlabelParameterVersionOutput, error)
 }
 
 // ParameterStore holds all the methods tha are supported against AWS Parameter Store
@@ -35,21 +92,148 @@ type ParameterStore struct {
 //
 // This will also page through and return all elements in the hierarchy, non-recursively
 func (ps *ParameterStore) GetAllParametersByPath(path string, decrypt bool) (*Parameters, error) {
+	return ps.GetAllParametersByPathWithContext(context.Background(), path, decrypt)
+}
+
+// GetAllParametersByPathWithContext is the same as GetAllParametersByPath with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetAllParametersByPathWithContext(ctx context.Context, path string, decrypt bool) (*Parameters, error) {
+	return ps.getAllParametersByPath(ctx, path, decrypt, false)
+}
+
+// GetAllParametersByPathWithFilters is the same as GetAllParametersByPath only returning the parameters matching every given filter
+// For example a request with path as /my-service/dev/ and FilterLabel("prod-approved")
+// Will return the versions of the parameters under /my-service/dev/ labelled prod-approved
+// ErrParameterInvalidFilter is returned for the filters other than FilterType, FilterKeyID and FilterLabel,
+// as AWS Parameter Store doesn't support them when fetching by path
+func (ps *ParameterStore) GetAllParametersByPathWithFilters(path string, decrypt bool, filters ...ParameterFilter) (*Parameters, error) {
+	return ps.GetAllParametersByPathWithFiltersWithContext(context.Background(), path, decrypt, filters...)
+}
+
+// GetAllParametersByPathWithFiltersWithContext is the same as GetAllParametersByPathWithFilters with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetAllParametersByPathWithFiltersWithContext(ctx context.Context, path string, decrypt bool, filters ...ParameterFilter) (*Parameters, error) {
+	input := newGetParametersByPathInput(path, decrypt, false)
+	for _, filter := range filters {
+		if !pathFilterKey(filter.Key) {
+			return nil, ErrParameterInvalidFilter
+		}
+		input.ParameterFilters = append(input.ParameterFilters, filter.toSSM())
+	}
+	return ps.getParameters(ctx, input)
+}
+
+// GetAllParametersByPathRecursive is returning all the Parameters that are hierarchy linked to this path, including nested ones
+// For example a request with path as /my-service/dev/
+// Will return /my-service/dev/param-a, /my-service/dev/db/host, /my-service/dev/db/port, etc...
+// The parameters keep their relative sub-paths, so /my-service/dev/db/host can be accessed with GetValueByName("db/host")
+// the `ssm:GetAllParametersByPath` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/*`
+func (ps *ParameterStore) GetAllParametersByPathRecursive(path string, decrypt bool) (*Parameters, error) {
+	return ps.GetAllParametersByPathRecursiveWithContext(context.Background(), path, decrypt)
+}
+
+// GetAllParametersByPathRecursiveWithContext is the same as GetAllParametersByPathRecursive with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetAllParametersByPathRecursiveWithContext(ctx context.Context, path string, decrypt bool) (*Parameters, error) {
+	return ps.getAllParametersByPath(ctx, path, decrypt, true)
+}
+
+// GetAllParametersByPathsOptions holds the optional settings of GetAllParametersByPathsWithOptions
+type GetAllParametersByPathsOptions struct {
+	// Decrypt the SecureString parameters
+	Decrypt bool
+	// Recursive also fetches the nested paths, as GetAllParametersByPathRecursive does
+	Recursive bool
+	// Workers is the number of paths fetched at the same time, 4 is used when empty
+	Workers int
+}
+
+// GetAllParametersByPaths is the same as GetAllParametersByPath for several paths, fetching up to 4 of them at the same time
+// The returned Parameters are in the same order as the paths. When some of the paths fail the returned error joins all
+// their errors, and the Parameters of the failed paths are nil
+func (ps *ParameterStore) GetAllParametersByPaths(paths []string, decrypt bool) ([]*Parameters, error) {
+	return ps.GetAllParametersByPathsWithOptionsWithContext(context.Background(), paths, GetAllParametersByPathsOptions{Decrypt: decrypt})
+}
+
+// GetAllParametersByPathsWithContext is the same as GetAllParametersByPaths with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetAllParametersByPathsWithContext(ctx context.Context, paths []string, decrypt bool) ([]*Parameters, error) {
+	return ps.GetAllParametersByPathsWithOptionsWithContext(ctx, paths, GetAllParametersByPathsOptions{Decrypt: decrypt})
+}
+
+// GetAllParametersByPathsWithOptions is the same as GetAllParametersByPaths using the decryption, recursion
+// and number of workers of the options
+func (ps *ParameterStore) GetAllParametersByPathsWithOptions(paths []string, options GetAllParametersByPathsOptions) ([]*Parameters, error) {
+	return ps.GetAllParametersByPathsWithOptionsWithContext(context.Background(), paths, options)
+}
+
+// GetAllParametersByPathsWithOptionsWithContext is the same as GetAllParametersByPathsWithOptions with the addition of
+// the ability to pass a context for cancellation and deadlines
+// Once the context is done the paths that weren't requested yet fail with the context error
+func (ps *ParameterStore) GetAllParametersByPathsWithOptionsWithContext(ctx context.Context, paths []string, options GetAllParametersByPathsOptions) ([]*Parameters, error) {
+	results := make([]*Parameters, len(paths))
+	errs := make([]error, len(paths))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	workers := options.Workers
+	if workers <= 0 {
+		workers = defaultPathsWorkers
+	}
+	if len(paths) < workers {
+		workers = len(paths)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				parameters, err := ps.getAllParametersByPath(ctx, paths[i], options.Decrypt, options.Recursive)
+				if err != nil {
+					errs[i] = fmt.Errorf("path %s: %w", paths[i], err)
+					continue
+				}
+				results[i] = parameters
+			}
+		}()
+	}
+feed:
+	for i := range paths {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for ; i < len(paths); i++ {
+				errs[i] = fmt.Errorf("path %s: %w", paths[i], ctx.Err())
+			}
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
+
+func (ps *ParameterStore) getAllParametersByPath(ctx context.Context, path string, decrypt, recursive bool) (*Parameters, error) {
+	return ps.getParameters(ctx, newGetParametersByPathInput(path, decrypt, recursive))
+}
+
+func newGetParametersByPathInput(path string, decrypt, recursive bool) *ssm.GetParametersByPathInput {
 	var input = &ssm.GetParametersByPathInput{}
 	input.SetWithDecryption(decrypt)
 	input.SetPath(path)
+	input.SetRecursive(recursive)
 	input.SetMaxResults(10)
-	return ps.getParameters(input)
+	return input
 }
 
-func (ps *ParameterStore) getParameters(input *ssm.GetParametersByPathInput) (*Parameters, error) {
+func (ps *ParameterStore) getParameters(ctx context.Context, input *ssm.GetParametersByPathInput) (*Parameters, error) {
 	parameters := NewParameters(*input.Path, make(map[string]*Parameter))
-	if err := ps.ssm.GetParametersByPathPages(input, func(result *ssm.GetParametersByPathOutput, b bool) bool {
+	if err := ps.ssm.GetParametersByPathPagesWithContext(ctx, input, func(result *ssm.GetParametersByPathOutput, b bool) bool {
 		for _, v := range result.Parameters {
 			if v.Name == nil {
 				continue
 			}
-			parameters.parameters[*v.Name] = &Parameter{Value: v.Value}
+			parameters.parameters[*v.Name] = newParameter(v)
 		}
 		return !b
 	}); err != nil {
@@ -64,25 +248,222 @@ func (ps *ParameterStore) getParameters(input *ssm.GetParametersByPathInput) (*P
 // The `ssm:GetParameter` permission is required
 // to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
 func (ps *ParameterStore) GetParameter(name string, decrypted bool) (*Parameter, error) {
+	return ps.GetParameterWithContext(context.Background(), name, decrypted)
+}
+
+// GetParameterWithContext is the same as GetParameter with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetParameterWithContext(ctx context.Context, name string, decrypted bool) (*Parameter, error) {
 	if name == "" {
 		return nil, ErrParameterInvalidName
 	}
 	input := &ssm.GetParameterInput{}
 	input.SetName(name)
 	input.SetWithDecryption(decrypted)
-	return ps.getParameter(input)
+	return ps.getParameter(ctx, input)
+}
+
+// GetParameterVersion is returning the given version of the parameter with the given name
+// For example a request with name as /my-service/dev/param-1 and version as 3
+// Will request /my-service/dev/param-1:3 and return the parameter value if exists or ErrParameterNotFound if it cannot be found
+// The resolved version and selector are available on the returned Parameter.
+// The `ssm:GetParameter` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) GetParameterVersion(name string, version int64, decrypted bool) (*Parameter, error) {
+	return ps.GetParameterVersionWithContext(context.Background(), name, version, decrypted)
+}
+
+// GetParameterVersionWithContext is the same as GetParameterVersion with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetParameterVersionWithContext(ctx context.Context, name string, version int64, decrypted bool) (*Parameter, error) {
+	if version <= 0 {
+		return nil, ErrParameterInvalidSelector
+	}
+	return ps.getParameterWithSelector(ctx, name, strconv.FormatInt(version, 10), decrypted)
+}
+
+// GetParameterByLabel is returning the version of the parameter with the given name that has the given label
+// For example a request with name as /my-service/dev/param-1 and label as prod-approved
+// Will request /my-service/dev/param-1:prod-approved and return the parameter value if exists or ErrParameterNotFound if it cannot be found
+// ErrParameterInvalidSelector is returned for labels that contain a colon or begin with a number, aws or ssm
+// The resolved version and selector are available on the returned Parameter.
+// The `ssm:GetParameter` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) GetParameterByLabel(name, label string, decrypted bool) (*Parameter, error) {
+	return ps.GetParameterByLabelWithContext(context.Background(), name, label, decrypted)
+}
+
+// GetParameterByLabelWithContext is the same as GetParameterByLabel with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetParameterByLabelWithContext(ctx context.Context, name, label string, decrypted bool) (*Parameter, error) {
+	if !isValidLabel(label) {
+		return nil, ErrParameterInvalidSelector
+	}
+	return ps.getParameterWithSelector(ctx, name, label, decrypted)
+}
+
+// isValidLabel reports if the label can select a parameter version, labels can't contain a colon
+// and can't begin with a number, aws or ssm, otherwise AWS Parameter Store would read them as a version
+func isValidLabel(label string) bool {
+	if label == "" || strings.Contains(label, ":") || (label[0] >= '0' && label[0] <= '9') {
+		return false
+	}
+	lower := strings.ToLower(label)
+	return !strings.HasPrefix(lower, "aws") && !strings.HasPrefix(lower, "ssm")
+}
+
+func (ps *ParameterStore) getParameterWithSelector(ctx context.Context, name, selector string, decrypted bool) (*Parameter, error) {
+	if name == "" || strings.Contains(name, ":") {
+		return nil, ErrParameterInvalidName
+	}
+	input := &ssm.GetParameterInput{}
+	input.SetName(name + ":" + selector)
+	input.SetWithDecryption(decrypted)
+	return ps.getParameter(ctx, input)
 }
-func (ps *ParameterStore) getParameter(input *ssm.GetParameterInput) (*Parameter, error) {
-	result, err := ps.ssm.GetParameter(input)
+
+func (ps *ParameterStore) getParameter(ctx context.Context, input *ssm.GetParameterInput) (*Parameter, error) {
+	result, err := ps.ssm.GetParameterWithContext(ctx, input)
 	if err != nil {
 		if awsError, ok := err.(awserr.Error); ok && awsError.Code() == ssm.ErrCodeParameterNotFound {
 			return nil, ErrParameterNotFound
 		}
 		return nil, err
 	}
-	return &Parameter{
-		Value: result.Parameter.Value,
-	}, nil
+	return newParameter(result.Parameter), nil
+}
+
+// GetParameters is returning the parameters with the given names, which don't need to share a path
+// For example a request with names as /my-service/dev/param-1 and /shared/dev/param-2
+// Will return the Parameters, accessible with GetValueByFullPath, and the list of names that could not be found.
+// The names can select a version or label, as in /my-service/dev/param-1:3, the Parameters are then accessible with that name.
+// The names are requested in batches of 10, the `ssm:GetParameters` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` and `arn:aws:ssm:aws-region:aws-account-id:/shared/dev/param-2` resources
+func (ps *ParameterStore) GetParameters(names []string, decrypt bool) (*Parameters, []string, error) {
+	return ps.GetParametersWithContext(context.Background(), names, decrypt)
+}
+
+// GetParametersWithContext is the same as GetParameters with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetParametersWithContext(ctx context.Context, names []string, decrypt bool) (*Parameters, []string, error) {
+	for _, name := range names {
+		if name == "" {
+			return nil, nil, ErrParameterInvalidName
+		}
+	}
+	parameters := NewParameters("", make(map[string]*Parameter, len(names)))
+	var invalidNames []string
+	for _, chunk := range chunkNames(names) {
+		input := &ssm.GetParametersInput{}
+		input.SetNames(aws.StringSlice(chunk))
+		input.SetWithDecryption(decrypt)
+		result, err := ps.ssm.GetParametersWithContext(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, v := range result.Parameters {
+			if v.Name == nil {
+				continue
+			}
+			// the selector of the requested name is returned apart, so versions of the same parameter don't overwrite each other
+			parameters.parameters[*v.Name+aws.StringValue(v.Selector)] = newParameter(v)
+		}
+		invalidNames = append(invalidNames, aws.StringValueSlice(result.InvalidParameters)...)
+	}
+	return parameters, invalidNames, nil
+}
+
+// chunkNames removes the duplicated names and splits them in batches that fit in a single request
+func chunkNames(names []string) [][]string {
+	seen := make(map[string]bool, len(names))
+	var chunks [][]string
+	var chunk []string
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		chunk = append(chunk, name)
+		if len(chunk) == maxNamesPerRequest {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// DescribeParameters is returning the details, without the values, of all the parameters matching every given filter
+// For example a request with FilterNameBeginsWith("/my-service/") and FilterTag("owner", "payments")
+// Will return all the parameters under /my-service/ owned by payments, paging through every result
+// ErrParameterInvalidFilter is returned for FilterLabel, as AWS Parameter Store doesn't support it here
+// The `ssm:DescribeParameters` permission is required
+func (ps *ParameterStore) DescribeParameters(filters ...ParameterFilter) ([]ParameterMetadata, error) {
+	return ps.DescribeParametersWithContext(context.Background(), filters...)
+}
+
+// DescribeParametersWithContext is the same as DescribeParameters with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) DescribeParametersWithContext(ctx context.Context, filters ...ParameterFilter) ([]ParameterMetadata, error) {
+	input := &ssm.DescribeParametersInput{}
+	for _, filter := range filters {
+		if !describeFilterKey(filter.Key) {
+			return nil, ErrParameterInvalidFilter
+		}
+		input.ParameterFilters = append(input.ParameterFilters, filter.toSSM())
+	}
+	input.SetMaxResults(50)
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	var metadata []ParameterMetadata
+	if err := ps.ssm.DescribeParametersPagesWithContext(ctx, input, func(result *ssm.DescribeParametersOutput, lastPage bool) bool {
+		for _, v := range result.Parameters {
+			metadata = append(metadata, newParameterMetadata(v))
+		}
+		return !lastPage
+	}); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// GetParameterHistory is returning all the versions of the parameter with the given name, ordered from the oldest to the latest
+// For example a request with name as /my-service/dev/param-1
+// Will return every value the parameter had, with who and when changed it, or ErrParameterNotFound if parameter cannot be found
+// The `ssm:GetParameterHistory` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) GetParameterHistory(name string, decrypt bool) ([]ParameterHistory, error) {
+	return ps.GetParameterHistoryWithContext(context.Background(), name, decrypt)
+}
+
+// GetParameterHistoryWithContext is the same as GetParameterHistory with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) GetParameterHistoryWithContext(ctx context.Context, name string, decrypt bool) ([]ParameterHistory, error) {
+	if name == "" {
+		return nil, ErrParameterInvalidName
+	}
+	input := &ssm.GetParameterHistoryInput{}
+	input.SetName(name)
+	input.SetWithDecryption(decrypt)
+	var history []ParameterHistory
+	if err := ps.ssm.GetParameterHistoryPagesWithContext(ctx, input, func(result *ssm.GetParameterHistoryOutput, lastPage bool) bool {
+		for _, v := range result.Parameters {
+			history = append(history, newParameterHistory(v))
+		}
+		return !lastPage
+	}); err != nil {
+		if awsError, ok := err.(awserr.Error); ok && awsError.Code() == ssm.ErrCodeParameterNotFound {
+			return nil, ErrParameterNotFound
+		}
+		return nil, err
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Version < history[j].Version
+	})
+	return history, nil
 }
 
 // PutSecureParameter is setting the parameter with the given name to a passed in value.
@@ -92,7 +473,13 @@ func (ps *ParameterStore) getParameter(input *ssm.GetParameterInput) (*Parameter
 // and `overwrite` is false. The `ssm:PutParameter` permission is required to the
 // `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
 func (ps *ParameterStore) PutSecureParameter(name, value string, overwrite bool) error {
-	return ps.putSecureParameterWrapper(name, value, "", overwrite)
+	return ps.PutSecureParameterWithContext(context.Background(), name, value, overwrite)
+}
+
+// PutSecureParameterWithContext is the same as PutSecureParameter with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) PutSecureParameterWithContext(ctx context.Context, name, value string, overwrite bool) error {
+	return ps.putSecureParameterWrapper(ctx, name, value, "", overwrite)
 }
 
 // PutSecureParameterWithCMK is the same as PutSecureParameter but with a passed in CMK (Customer Master Key)
@@ -102,29 +489,158 @@ func (ps *ParameterStore) PutSecureParameter(name, value string, overwrite bool)
 // `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
 // The `kms:Encrypt` permission is required to the `arn:aws:kms:us-east-1:710015040892:key/foo`
 func (ps *ParameterStore) PutSecureParameterWithCMK(name, value string, overwrite bool, kmsID string) error {
-	return ps.putSecureParameterWrapper(name, value, kmsID, overwrite)
+	return ps.PutSecureParameterWithCMKWithContext(context.Background(), name, value, overwrite, kmsID)
+}
+
+// PutSecureParameterWithCMKWithContext is the same as PutSecureParameterWithCMK with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) PutSecureParameterWithCMKWithContext(ctx context.Context, name, value string, overwrite bool, kmsID string) error {
+	return ps.putSecureParameterWrapper(ctx, name, value, kmsID, overwrite)
+}
+func (ps *ParameterStore) putSecureParameterWrapper(ctx context.Context, name, value, kmsID string, overwrite bool) error {
+	return ps.putParameterWithOptions(ctx, name, value, PutParameterOptions{
+		Type:      ParameterTypeSecureString,
+		KMSKeyID:  kmsID,
+		Overwrite: overwrite,
+	})
+}
+
+// PutParameterOptions holds the optional settings of a parameter that is set with PutParameterWithOptions
+type PutParameterOptions struct {
+	// Type of the parameter, String is used when empty
+	Type string
+	// Description of the parameter
+	Description string
+	// Tier of the parameter, the account default is used when empty
+	// unless the value is larger than 4KB, then Intelligent-Tiering is used
+	Tier string
+	// AllowedPattern is a regular expression the value has to match
+	AllowedPattern string
+	// DataType of the parameter, for example text or aws:ec2:image
+	DataType string
+	// KMSKeyID is the CMK (Customer Master Key) used to encrypt a SecureString parameter
+	KMSKeyID string
+	// Overwrite allows overwriting the value of an existing parameter
+	Overwrite bool
+	// Tags are added to the parameter, keeping the tags it already has
+	Tags map[string]string
+	// Policies assigned to the parameter, they are only supported by advanced parameters
+	Policies []Policy
+}
+
+// PutParameter is setting the String parameter with the given name to a passed in value.
+// Allow overwriting the value of the parameter already exists, otherwise an error is returned
+// For example a request with name as '/my-service/dev/param-1':
+// Will set the parameter value if exists or ErrParameterInvalidName if parameter already exists or is empty
+// and `overwrite` is false. The `ssm:PutParameter` permission is required to the
+// `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) PutParameter(name, value string, overwrite bool) error {
+	return ps.PutParameterWithContext(context.Background(), name, value, overwrite)
+}
+
+// PutParameterWithContext is the same as PutParameter with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) PutParameterWithContext(ctx context.Context, name, value string, overwrite bool) error {
+	return ps.putParameterWithOptions(ctx, name, value, PutParameterOptions{
+		Type:      ParameterTypeString,
+		Overwrite: overwrite,
+	})
 }
-func (ps *ParameterStore) putSecureParameterWrapper(name, value, kmsID string, overwrite bool) error {
+
+// PutStringListParameter is the same as PutParameter but sets a StringList parameter with the passed in values.
+// ErrParameterInvalidValue is returned when any of the values contains a comma, as it is the StringList separator
+func (ps *ParameterStore) PutStringListParameter(name string, values []string, overwrite bool) error {
+	return ps.PutStringListParameterWithContext(context.Background(), name, values, overwrite)
+}
+
+// PutStringListParameterWithContext is the same as PutStringListParameter with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) PutStringListParameterWithContext(ctx context.Context, name string, values []string, overwrite bool) error {
+	for _, value := range values {
+		if strings.Contains(value, ",") {
+			return ErrParameterInvalidValue
+		}
+	}
+	return ps.putParameterWithOptions(ctx, name, strings.Join(values, ","), PutParameterOptions{
+		Type:      ParameterTypeStringList,
+		Overwrite: overwrite,
+	})
+}
+
+// PutParameterWithOptions is setting the parameter with the given name to a passed in value,
+// using the type, description, tier, allowed pattern, data type and CMK of the options
+// Will set the parameter value if exists or ErrParameterInvalidName if parameter already exists or is empty
+// and `Overwrite` is false. The `ssm:PutParameter` permission is required to the
+// `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) PutParameterWithOptions(name, value string, options PutParameterOptions) error {
+	return ps.PutParameterWithOptionsWithContext(context.Background(), name, value, options)
+}
+
+// PutParameterWithOptionsWithContext is the same as PutParameterWithOptions with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) PutParameterWithOptionsWithContext(ctx context.Context, name, value string, options PutParameterOptions) error {
+	return ps.putParameterWithOptions(ctx, name, value, options)
+}
+
+func (ps *ParameterStore) putParameterWithOptions(ctx context.Context, name, value string, options PutParameterOptions) error {
 	if name == "" {
 		return ErrParameterInvalidName
 	}
 	input := &ssm.PutParameterInput{}
 	input.SetName(name)
-	input.SetType("SecureString")
+	if options.Type == "" {
+		options.Type = ParameterTypeString
+	}
+	input.SetType(options.Type)
 	input.SetValue(value)
-	if kmsID != "" {
-		input.SetKeyId(kmsID)
+	if options.Description != "" {
+		input.SetDescription(options.Description)
+	}
+	if len(value) > maxAdvancedValueSize {
+		return ErrParameterTooLarge
+	}
+	if options.Tier == "" && len(value) > maxStandardValueSize {
+		options.Tier = ParameterTierIntelligentTiering
+	}
+	if options.Tier != "" {
+		input.SetTier(options.Tier)
+	}
+	if options.AllowedPattern != "" {
+		input.SetAllowedPattern(options.AllowedPattern)
+	}
+	if options.DataType != "" {
+		input.SetDataType(options.DataType)
+	}
+	if options.KMSKeyID != "" {
+		input.SetKeyId(options.KMSKeyID)
+	}
+	if len(options.Policies) > 0 {
+		policies, err := marshalPolicies(options.Policies)
+		if err != nil {
+			return err
+		}
+		input.SetPolicies(policies)
+	}
+	input.SetOverwrite(options.Overwrite)
+	// AWS Parameter Store doesn't accept tags when overwriting a parameter, so they are added after the put
+	if len(options.Tags) > 0 && !options.Overwrite {
+		input.SetTags(newTags(options.Tags))
 	}
-	input.SetOverwrite(overwrite)
 
 	if err := input.Validate(); err != nil {
 		return err
 	}
 
-	return ps.putParameter(input)
+	if err := ps.putParameter(ctx, input); err != nil {
+		return err
+	}
+	if len(options.Tags) > 0 && options.Overwrite {
+		return ps.AddTagsWithContext(ctx, name, options.Tags)
+	}
+	return nil
 }
-func (ps *ParameterStore) putParameter(input *ssm.PutParameterInput) error {
-	_, err := ps.ssm.PutParameter(input)
+func (ps *ParameterStore) putParameter(ctx context.Context, input *ssm.PutParameterInput) error {
+	_, err := ps.ssm.PutParameterWithContext(ctx, input)
 	if err != nil {
 		if awsError, ok := err.(awserr.Error); ok && awsError.Code() == ssm.ErrCodeParameterAlreadyExists {
 			return ErrParameterInvalidName
@@ -134,6 +650,232 @@ func (ps *ParameterStore) putParameter(input *ssm.PutParameterInput) error {
 	return nil
 }
 
+// DeleteParameter is deleting the parameter with the given name
+// For example a request with name as /my-service/dev/param-1
+// Will delete the parameter if exists or return ErrParameterNotFound if parameter cannot be found
+// The `ssm:DeleteParameter` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) DeleteParameter(name string) error {
+	return ps.DeleteParameterWithContext(context.Background(), name)
+}
+
+// DeleteParameterWithContext is the same as DeleteParameter with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) DeleteParameterWithContext(ctx context.Context, name string) error {
+	if name == "" {
+		return ErrParameterInvalidName
+	}
+	input := &ssm.DeleteParameterInput{}
+	input.SetName(name)
+	_, err := ps.ssm.DeleteParameterWithContext(ctx, input)
+	if err != nil {
+		if awsError, ok := err.(awserr.Error); ok && awsError.Code() == ssm.ErrCodeParameterNotFound {
+			return ErrParameterNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteParameters is deleting the parameters with the given names
+// Will return the list of names that could not be deleted because they are invalid or cannot be found.
+// The names are deleted in batches of 10, the `ssm:DeleteParameters` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resources
+func (ps *ParameterStore) DeleteParameters(names []string) ([]string, error) {
+	return ps.DeleteParametersWithContext(context.Background(), names)
+}
+
+// DeleteParametersWithContext is the same as DeleteParameters with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) DeleteParametersWithContext(ctx context.Context, names []string) ([]string, error) {
+	for _, name := range names {
+		if name == "" {
+			return nil, ErrParameterInvalidName
+		}
+	}
+	var invalidNames []string
+	for _, chunk := range chunkNames(names) {
+		input := &ssm.DeleteParametersInput{}
+		input.SetNames(aws.StringSlice(chunk))
+		result, err := ps.ssm.DeleteParametersWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		invalidNames = append(invalidNames, aws.StringValueSlice(result.InvalidParameters)...)
+	}
+	return invalidNames, nil
+}
+
+// AddTags is adding the tags to the parameter with the given name, replacing the values of the existing tag keys
+// Will return ErrParameterNotFound if parameter cannot be found
+// The `ssm:AddTagsToResource` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) AddTags(name string, tags map[string]string) error {
+	return ps.AddTagsWithContext(context.Background(), name, tags)
+}
+
+// AddTagsWithContext is the same as AddTags with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) AddTagsWithContext(ctx context.Context, name string, tags map[string]string) error {
+	if name == "" {
+		return ErrParameterInvalidName
+	}
+	input := &ssm.AddTagsToResourceInput{}
+	input.SetResourceType(ssm.ResourceTypeForTaggingParameter)
+	input.SetResourceId(name)
+	input.SetTags(newTags(tags))
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	_, err := ps.ssm.AddTagsToResourceWithContext(ctx, input)
+	return mapTagsError(err)
+}
+
+// RemoveTags is removing the tags with the given keys from the parameter with the given name
+// Will return ErrParameterNotFound if parameter cannot be found
+// The `ssm:RemoveTagsFromResource` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) RemoveTags(name string, keys []string) error {
+	return ps.RemoveTagsWithContext(context.Background(), name, keys)
+}
+
+// RemoveTagsWithContext is the same as RemoveTags with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) RemoveTagsWithContext(ctx context.Context, name string, keys []string) error {
+	if name == "" {
+		return ErrParameterInvalidName
+	}
+	input := &ssm.RemoveTagsFromResourceInput{}
+	input.SetResourceType(ssm.ResourceTypeForTaggingParameter)
+	input.SetResourceId(name)
+	input.SetTagKeys(aws.StringSlice(keys))
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	_, err := ps.ssm.RemoveTagsFromResourceWithContext(ctx, input)
+	return mapTagsError(err)
+}
+
+// ListTags is returning the tags of the parameter with the given name
+// Will return ErrParameterNotFound if parameter cannot be found
+// The `ssm:ListTagsForResource` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) ListTags(name string) (map[string]string, error) {
+	return ps.ListTagsWithContext(context.Background(), name)
+}
+
+// ListTagsWithContext is the same as ListTags with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) ListTagsWithContext(ctx context.Context, name string) (map[string]string, error) {
+	if name == "" {
+		return nil, ErrParameterInvalidName
+	}
+	input := &ssm.ListTagsForResourceInput{}
+	input.SetResourceType(ssm.ResourceTypeForTaggingParameter)
+	input.SetResourceId(name)
+	result, err := ps.ssm.ListTagsForResourceWithContext(ctx, input)
+	if err != nil {
+		return nil, mapTagsError(err)
+	}
+	tags := make(map[string]string, len(result.TagList))
+	for _, tag := range result.TagList {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return tags, nil
+}
+
+// newTags converts the tags to the AWS format, sorted by key so the requests are deterministic
+func newTags(tags map[string]string) []*ssm.Tag {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]*ssm.Tag, 0, len(tags))
+	for _, key := range keys {
+		result = append(result, new(ssm.Tag).SetKey(key).SetValue(tags[key]))
+	}
+	return result
+}
+
+func mapTagsError(err error) error {
+	if awsError, ok := err.(awserr.Error); ok && awsError.Code() == ssm.ErrCodeInvalidResourceId {
+		return ErrParameterNotFound
+	}
+	return err
+}
+
+// LabelParameterVersion is attaching the labels to the given version of the parameter with the given name
+// A version of 0 is labelling the latest version of the parameter.
+// Will return ErrParameterNotFound if parameter cannot be found or an *InvalidLabelsError with the labels that were rejected
+// The `ssm:LabelParameterVersion` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) LabelParameterVersion(name string, version int64, labels []string) error {
+	return ps.LabelParameterVersionWithContext(context.Background(), name, version, labels)
+}
+
+// LabelParameterVersionWithContext is the same as LabelParameterVersion with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) LabelParameterVersionWithContext(ctx context.Context, name string, version int64, labels []string) error {
+	if name == "" || strings.Contains(name, ":") {
+		return ErrParameterInvalidName
+	}
+	if version < 0 || len(labels) == 0 {
+		return ErrParameterInvalidSelector
+	}
+	input := &ssm.LabelParameterVersionInput{}
+	input.SetName(name)
+	if version > 0 {
+		input.SetParameterVersion(version)
+	}
+	input.SetLabels(aws.StringSlice(labels))
+	result, err := ps.ssm.LabelParameterVersionWithContext(ctx, input)
+	if err != nil {
+		if awsError, ok := err.(awserr.Error); ok && awsError.Code() == ssm.ErrCodeParameterNotFound {
+			return ErrParameterNotFound
+		}
+		return err
+	}
+	if len(result.InvalidLabels) > 0 {
+		return &InvalidLabelsError{Name: name, Labels: aws.StringValueSlice(result.InvalidLabels)}
+	}
+	return nil
+}
+
+// UnlabelParameterVersion is removing the labels from the given version of the parameter with the given name
+// Will return ErrParameterNotFound if parameter cannot be found or an *InvalidLabelsError with the labels that could not be removed
+// The `ssm:UnlabelParameterVersion` permission is required
+// to the `arn:aws:ssm:aws-region:aws-account-id:/my-service/dev/param-1` resource
+func (ps *ParameterStore) UnlabelParameterVersion(name string, version int64, labels []string) error {
+	return ps.UnlabelParameterVersionWithContext(context.Background(), name, version, labels)
+}
+
+// UnlabelParameterVersionWithContext is the same as UnlabelParameterVersion with the addition of
+// the ability to pass a context for cancellation and deadlines
+func (ps *ParameterStore) UnlabelParameterVersionWithContext(ctx context.Context, name string, version int64, labels []string) error {
+	if name == "" || strings.Contains(name, ":") {
+		return ErrParameterInvalidName
+	}
+	if version <= 0 || len(labels) == 0 {
+		return ErrParameterInvalidSelector
+	}
+	input := &ssm.UnlabelParameterVersionInput{}
+	input.SetName(name)
+	input.SetParameterVersion(version)
+	input.SetLabels(aws.StringSlice(labels))
+	result, err := ps.ssm.UnlabelParameterVersionWithContext(ctx, input)
+	if err != nil {
+		if awsError, ok := err.(awserr.Error); ok && awsError.Code() == ssm.ErrCodeParameterNotFound {
+			return ErrParameterNotFound
+		}
+		return err
+	}
+	if len(result.InvalidLabels) > 0 {
+		return &InvalidLabelsError{Name: name, Labels: aws.StringValueSlice(result.InvalidLabels)}
+	}
+	return nil
+}
+
 // NewParameterStoreWithClient is creating a new ParameterStore with the given ssm Client
 func NewParameterStoreWithClient(client ssmClient) *ParameterStore {
 	return &ParameterStore{ssm: client}
@@ -1,11 +1,19 @@
 package awsssm
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
 
@@ -35,12 +43,43 @@ type stubGetParametersByPathOutput struct {
 type stubSSMClient struct {
 	GetParametersByPathOutput []stubGetParametersByPathOutput
 	GetParametersByPathError  error
-	GetParameterOutput        *ssm.GetParameterOutput
-	GetParameterError         error
-	PutParameterInputReceived *ssm.PutParameterInput
+	GetParametersByPathInput  *ssm.GetParametersByPathInput
+	// GetParametersByPathRequests counts the requests of single pages
+	GetParametersByPathRequests int
+	GetParameterOutput          *ssm.GetParameterOutput
+	GetParameterError           error
+	GetParameterInputReceived   *ssm.GetParameterInput
+	PutParameterInputReceived   *ssm.PutParameterInput
+	PutParameterError           error
+	// GetParametersValues are the parameters known to GetParameters, names not found are returned as invalid
+	GetParametersValues         map[string]*ssm.Parameter
+	GetParametersError          error
+	GetParametersInputsReceived []*ssm.GetParametersInput
+	DeleteParameterError        error
+	// DeleteParametersValues are the names known to DeleteParameters, other names are returned as invalid
+	DeleteParametersValues         map[string]bool
+	DeleteParametersInputsReceived []*ssm.DeleteParametersInput
+	GetParameterHistoryOutput      []ssm.GetParameterHistoryOutput
+	GetParameterHistoryError       error
+	DescribeParametersOutput       []ssm.DescribeParametersOutput
+	DescribeParametersError        error
+	DescribeParametersInput        *ssm.DescribeParametersInput
+	// InvalidLabels are the labels rejected by LabelParameterVersion and UnlabelParameterVersion
+	InvalidLabels                        []string
+	LabelParameterVersionError           error
+	LabelParameterVersionInputReceived   *ssm.LabelParameterVersionInput
+	UnlabelParameterVersionInputReceived *ssm.UnlabelParameterVersionInput
+	AddTagsInputReceived                 *ssm.AddTagsToResourceInput
+	RemoveTagsInputReceived              *ssm.RemoveTagsFromResourceInput
+	ListTagsOutput                       *ssm.ListTagsForResourceOutput
+	TagsError                            error
 }
 
-func (s *stubSSMClient) GetParametersByPathPages(input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool) error {
+func (s *stubSSMClient) GetParametersByPathPagesWithContext(ctx aws.Context, input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool, opts ...request.Option) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.GetParametersByPathInput = input
 	if s.GetParametersByPathError == nil {
 		for _, output := range s.GetParametersByPathOutput {
 			done := fn(&output.Output, output.MoreParamsLeft)
@@ -52,15 +91,161 @@ func (s *stubSSMClient) GetParametersByPathPages(input *ssm.GetParametersByPathI
 	return s.GetParametersByPathError
 }
 
-func (s *stubSSMClient) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+// GetParametersByPathWithContext serves the GetParametersByPathOutput pages using their index as the next token
+func (s *stubSSMClient) GetParametersByPathWithContext(ctx aws.Context, input *ssm.GetParametersByPathInput, opts ...request.Option) (*ssm.GetParametersByPathOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.GetParametersByPathInput = input
+	s.GetParametersByPathRequests++
+	if s.GetParametersByPathError != nil {
+		return nil, s.GetParametersByPathError
+	}
+	page := 0
+	if input.NextToken != nil {
+		page, _ = strconv.Atoi(*input.NextToken)
+	}
+	if page >= len(s.GetParametersByPathOutput) {
+		return &ssm.GetParametersByPathOutput{}, nil
+	}
+	output := s.GetParametersByPathOutput[page].Output
+	if page < len(s.GetParametersByPathOutput)-1 {
+		output.NextToken = aws.String(strconv.Itoa(page + 1))
+	}
+	return &output, nil
+}
+
+func (s *stubSSMClient) GetParameterWithContext(ctx aws.Context, input *ssm.GetParameterInput, opts ...request.Option) (*ssm.GetParameterOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.GetParameterInputReceived = input
 	return s.GetParameterOutput, s.GetParameterError
 }
 
+func (s *stubSSMClient) DescribeParametersPagesWithContext(ctx aws.Context, input *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool, opts ...request.Option) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.DescribeParametersInput = input
+	if s.DescribeParametersError != nil {
+		return s.DescribeParametersError
+	}
+	for i := range s.DescribeParametersOutput {
+		if !fn(&s.DescribeParametersOutput[i], i == len(s.DescribeParametersOutput)-1) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *stubSSMClient) GetParameterHistoryPagesWithContext(ctx aws.Context, input *ssm.GetParameterHistoryInput, fn func(*ssm.GetParameterHistoryOutput, bool) bool, opts ...request.Option) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.GetParameterHistoryError != nil {
+		return s.GetParameterHistoryError
+	}
+	for i := range s.GetParameterHistoryOutput {
+		if !fn(&s.GetParameterHistoryOutput[i], i == len(s.GetParameterHistoryOutput)-1) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *stubSSMClient) GetParametersWithContext(ctx aws.Context, input *ssm.GetParametersInput, opts ...request.Option) (*ssm.GetParametersOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.GetParametersInputsReceived = append(s.GetParametersInputsReceived, input)
+	if s.GetParametersError != nil {
+		return nil, s.GetParametersError
+	}
+	output := &ssm.GetParametersOutput{}
+	for _, name := range input.Names {
+		if parameter, ok := s.GetParametersValues[*name]; ok {
+			output.Parameters = append(output.Parameters, parameter)
+			continue
+		}
+		output.InvalidParameters = append(output.InvalidParameters, name)
+	}
+	return output, nil
+}
+
+func (s *stubSSMClient) DeleteParameterWithContext(ctx aws.Context, input *ssm.DeleteParameterInput, opts ...request.Option) (*ssm.DeleteParameterOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &ssm.DeleteParameterOutput{}, s.DeleteParameterError
+}
+
+func (s *stubSSMClient) DeleteParametersWithContext(ctx aws.Context, input *ssm.DeleteParametersInput, opts ...request.Option) (*ssm.DeleteParametersOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.DeleteParametersInputsReceived = append(s.DeleteParametersInputsReceived, input)
+	output := &ssm.DeleteParametersOutput{}
+	for _, name := range input.Names {
+		if s.DeleteParametersValues[*name] {
+			output.DeletedParameters = append(output.DeletedParameters, name)
+			continue
+		}
+		output.InvalidParameters = append(output.InvalidParameters, name)
+	}
+	return output, nil
+}
+
+func (s *stubSSMClient) LabelParameterVersionWithContext(ctx aws.Context, input *ssm.LabelParameterVersionInput, opts ...request.Option) (*ssm.LabelParameterVersionOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.LabelParameterVersionInputReceived = input
+	if s.LabelParameterVersionError != nil {
+		return nil, s.LabelParameterVersionError
+	}
+	return &ssm.LabelParameterVersionOutput{InvalidLabels: aws.StringSlice(s.InvalidLabels)}, nil
+}
+
+func (s *stubSSMClient) UnlabelParameterVersionWithContext(ctx aws.Context, input *ssm.UnlabelParameterVersionInput, opts ...request.Option) (*ssm.UnlabelParameterVersionOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.UnlabelParameterVersionInputReceived = input
+	return &ssm.UnlabelParameterVersionOutput{InvalidLabels: aws.StringSlice(s.InvalidLabels)}, nil
+}
+
+func (s *stubSSMClient) AddTagsToResourceWithContext(ctx aws.Context, input *ssm.AddTagsToResourceInput, opts ...request.Option) (*ssm.AddTagsToResourceOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.AddTagsInputReceived = input
+	return &ssm.AddTagsToResourceOutput{}, s.TagsError
+}
+
+func (s *stubSSMClient) RemoveTagsFromResourceWithContext(ctx aws.Context, input *ssm.RemoveTagsFromResourceInput, opts ...request.Option) (*ssm.RemoveTagsFromResourceOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.RemoveTagsInputReceived = input
+	return &ssm.RemoveTagsFromResourceOutput{}, s.TagsError
+}
+
+func (s *stubSSMClient) ListTagsForResourceWithContext(ctx aws.Context, input *ssm.ListTagsForResourceInput, opts ...request.Option) (*ssm.ListTagsForResourceOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.ListTagsOutput, s.TagsError
+}
+
 // we return nothing becuase the actual response is pretty boring. Just a version number. We DO
 // want to track was is input because there is a _little_ business logic around that
-func (s *stubSSMClient) PutParameter(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+func (s *stubSSMClient) PutParameterWithContext(ctx aws.Context, input *ssm.PutParameterInput, opts ...request.Option) (*ssm.PutParameterOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s.PutParameterInputReceived = input
-	return nil, nil
+	return nil, s.PutParameterError
 }
 
 func TestClient_GetParametersByPath(t *testing.T) {
@@ -101,9 +286,9 @@ func TestClient_GetParametersByPath(t *testing.T) {
 			expectedOutput: &Parameters{
 				basePath: "/my-service/dev/",
 				parameters: map[string]*Parameter{
-					"/my-service/dev/DB_PASSWORD": {Value: param1.Value},
-					"/my-service/dev/DB_HOST":     {Value: param2.Value},
-					"/my-service/dev/DB_USERNAME": {Value: param3.Value},
+					"/my-service/dev/DB_PASSWORD": newParameter(param1),
+					"/my-service/dev/DB_HOST":     newParameter(param2),
+					"/my-service/dev/DB_USERNAME": newParameter(param3),
 				},
 			},
 		},
@@ -132,6 +317,76 @@ func TestClient_GetParametersByPath(t *testing.T) {
 	}
 }
 
+func TestParameterStore_GetAllParametersByPathRecursive(t *testing.T) {
+	dbHost := new(ssm.Parameter).
+		SetName("/my-service/dev/db/host").
+		SetValue("rds.something.aws.com")
+	dbPort := new(ssm.Parameter).
+		SetName("/my-service/dev/db/port").
+		SetValue("5432")
+	stub := &stubSSMClient{
+		GetParametersByPathOutput: []stubGetParametersByPathOutput{
+			{
+				Output: ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{param1, dbHost, dbPort},
+				},
+			},
+		},
+	}
+
+	client := NewParameterStoreWithClient(stub)
+	parameters, err := client.GetAllParametersByPathRecursive("/my-service/dev/", true)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if !aws.BoolValue(stub.GetParametersByPathInput.Recursive) {
+		t.Error(`Expected a recursive GetParametersByPath request`)
+	}
+	if value := parameters.GetValueByName("db/host"); value != "rds.something.aws.com" {
+		t.Errorf(`Unexpected value: got %s, expected %s`, value, "rds.something.aws.com")
+	}
+	if value := parameters.GetValueByName("db/port"); value != "5432" {
+		t.Errorf(`Unexpected value: got %s, expected %s`, value, "5432")
+	}
+	if value := parameters.GetValueByName("DB_PASSWORD"); value != "something-secure" {
+		t.Errorf(`Unexpected value: got %s, expected %s`, value, "something-secure")
+	}
+}
+
+func TestParameterStore_GetParameterMetadata(t *testing.T) {
+	lastModified := time.Date(2019, 11, 4, 10, 30, 0, 0, time.UTC)
+	client := NewParameterStoreWithClient(&stubSSMClient{
+		GetParameterOutput: &ssm.GetParameterOutput{
+			Parameter: new(ssm.Parameter).
+				SetName("/my-service/dev/DB_PASSWORD").
+				SetValue("something-secure").
+				SetType(ssm.ParameterTypeSecureString).
+				SetVersion(3).
+				SetLastModifiedDate(lastModified).
+				SetARN("arn:aws:ssm:us-east-2:aws-account-id:/my-service/dev/DB_PASSWORD").
+				SetDataType("text").
+				SetSelector(":3"),
+		},
+	})
+	parameter, err := client.GetParameter("/my-service/dev/DB_PASSWORD", true)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expected := &Parameter{
+		Value:            aws.String("something-secure"),
+		Name:             "/my-service/dev/DB_PASSWORD",
+		Type:             ParameterTypeSecureString,
+		Version:          3,
+		LastModifiedDate: lastModified,
+		ARN:              "arn:aws:ssm:us-east-2:aws-account-id:/my-service/dev/DB_PASSWORD",
+		DataType:         "text",
+		Selector:         ":3",
+	}
+	if !reflect.DeepEqual(parameter, expected) {
+		t.Errorf(`Unexpected parameter: got %+v, expected %+v`, parameter, expected)
+	}
+}
+
 func getParameters() []*ssm.Parameter {
 	return []*ssm.Parameter{
 		param1, param2,
@@ -163,6 +418,8 @@ func TestParameterStore_GetParameter(t *testing.T) {
 			parameterName: "/my-service/dev/DB_PASSWORD",
 			expectedOutput: &Parameter{
 				Value: &value,
+				Name:  "/my-service/dev/DB_PASSWORD",
+				ARN:   "arn:aws:ssm:us-east-2:aws-account-id:/my-service/dev/DB_PASSWORD",
 			},
 		},
 		{
@@ -195,6 +452,109 @@ func TestParameterStore_GetParameter(t *testing.T) {
 	}
 }
 
+func TestParameterStore_GetParameters(t *testing.T) {
+	values := make(map[string]*ssm.Parameter)
+	var names []string
+	for i := 0; i < 25; i++ {
+		name := fmt.Sprintf("/service-%d/dev/param", i)
+		values[name] = new(ssm.Parameter).SetName(name).SetValue(fmt.Sprint(i))
+		names = append(names, name)
+	}
+	names = append(names, "/my-service/dev/NOT_FOUND", names[0])
+
+	tests := []struct {
+		name                 string
+		ssmClient            *stubSSMClient
+		names                []string
+		expectedError        error
+		expectedInvalidNames []string
+		expectedRequests     int
+	}{
+		{
+			name:                 "Success",
+			ssmClient:            &stubSSMClient{GetParametersValues: values},
+			names:                names,
+			expectedInvalidNames: []string{"/my-service/dev/NOT_FOUND"},
+			expectedRequests:     3,
+		},
+		{
+			name:          "Failed Empty name",
+			ssmClient:     &stubSSMClient{},
+			names:         []string{"/my-service/dev/DB_HOST", ""},
+			expectedError: ErrParameterInvalidName,
+		},
+		{
+			name:             "Failed SSM Request Error",
+			ssmClient:        &stubSSMClient{GetParametersError: errSSM},
+			names:            names,
+			expectedError:    errSSM,
+			expectedRequests: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewParameterStoreWithClient(test.ssmClient)
+			parameters, invalidNames, err := client.GetParameters(test.names, true)
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if len(test.ssmClient.GetParametersInputsReceived) != test.expectedRequests {
+				t.Errorf(`Unexpected requests: got %d, expected %d`, len(test.ssmClient.GetParametersInputsReceived), test.expectedRequests)
+			}
+			for _, input := range test.ssmClient.GetParametersInputsReceived {
+				if len(input.Names) > 10 {
+					t.Errorf(`Unexpected batch size: got %d, expected at most 10`, len(input.Names))
+				}
+			}
+			if !reflect.DeepEqual(invalidNames, test.expectedInvalidNames) {
+				t.Errorf(`Unexpected invalid names: got %v, expected %v`, invalidNames, test.expectedInvalidNames)
+			}
+			if err != nil {
+				return
+			}
+			for name, value := range values {
+				if got := parameters.GetValueByFullPath(name); got != *value.Value {
+					t.Errorf(`Unexpected value for %s: got %s, expected %s`, name, got, *value.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestParameterStore_GetParametersWithSelectors(t *testing.T) {
+	stub := &stubSSMClient{GetParametersValues: map[string]*ssm.Parameter{
+		"/my-service/dev/DB_HOST:1": new(ssm.Parameter).
+			SetName("/my-service/dev/DB_HOST").SetValue("rds.something-old.aws.com").SetVersion(1).SetSelector(":1"),
+		"/my-service/dev/DB_HOST:2": new(ssm.Parameter).
+			SetName("/my-service/dev/DB_HOST").SetValue("rds.something.aws.com").SetVersion(2).SetSelector(":2"),
+		"/my-service/dev/DB_HOST:prod-approved": new(ssm.Parameter).
+			SetName("/my-service/dev/DB_HOST").SetValue("rds.something.aws.com").SetVersion(2).SetSelector(":prod-approved"),
+		"/my-service/dev/DB_PASSWORD": param1,
+	}}
+	names := []string{
+		"/my-service/dev/DB_HOST:1",
+		"/my-service/dev/DB_HOST:2",
+		"/my-service/dev/DB_HOST:prod-approved",
+		"/my-service/dev/DB_PASSWORD",
+	}
+	parameters, invalidNames, err := NewParameterStoreWithClient(stub).GetParameters(names, true)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if len(invalidNames) != 0 {
+		t.Errorf(`Unexpected invalid names: %v`, invalidNames)
+	}
+	expected := map[string]string{
+		"/my-service/dev/DB_HOST:1":             "rds.something-old.aws.com",
+		"/my-service/dev/DB_HOST:2":             "rds.something.aws.com",
+		"/my-service/dev/DB_HOST:prod-approved": "rds.something.aws.com",
+		"/my-service/dev/DB_PASSWORD":           "something-secure",
+	}
+	if values := parameters.GetAllValues(); !reflect.DeepEqual(values, expected) {
+		t.Errorf(`Unexpected values: got %v, expected %v`, values, expected)
+	}
+}
+
 func TestParameterStore_PutSecureParameter(t *testing.T) {
 	paramName := "foo"
 	paramValue := "baz"
@@ -321,3 +681,898 @@ func TestParameterStore_PutSecureParameterWithCMK(t *testing.T) {
 		})
 	}
 }
+
+func TestParameterStore_WithContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewParameterStoreWithClient(&stubSSMClient{
+		GetParameterOutput: &ssm.GetParameterOutput{
+			Parameter: param1,
+		},
+	})
+	if _, err := client.GetAllParametersByPathWithContext(ctx, "/my-service/dev/", true); err != context.Canceled {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, context.Canceled)
+	}
+	if _, err := client.GetParameterWithContext(ctx, "/my-service/dev/DB_PASSWORD", true); err != context.Canceled {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, context.Canceled)
+	}
+	if err := client.PutSecureParameterWithContext(ctx, "foo", "baz", false); err != context.Canceled {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, context.Canceled)
+	}
+	if err := client.PutSecureParameterWithCMKWithContext(ctx, "foo", "baz", false, "kms"); err != context.Canceled {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, context.Canceled)
+	}
+}
+
+func TestParameterStore_PutParameter(t *testing.T) {
+	paramName := "foo"
+	paramValue := "baz"
+	paramType := "String"
+	overwriteTrue := true
+
+	client := NewParameterStoreWithClient(&stubSSMClient{})
+	if err := client.PutParameter("", paramValue, false); err != ErrParameterInvalidName {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterInvalidName)
+	}
+
+	stub := &stubSSMClient{}
+	client = NewParameterStoreWithClient(stub)
+	if err := client.PutParameter(paramName, paramValue, overwriteTrue); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expectedInput := &ssm.PutParameterInput{
+		Name:      &paramName,
+		Type:      &paramType,
+		Value:     &paramValue,
+		Overwrite: &overwriteTrue,
+	}
+	if !reflect.DeepEqual(stub.PutParameterInputReceived, expectedInput) {
+		t.Errorf(`Unexpected parameter: got %v, expected %v`, stub.PutParameterInputReceived, expectedInput)
+	}
+}
+
+func TestParameterStore_PutStringListParameter(t *testing.T) {
+	paramName := "foo"
+	paramValue := "a,b,c"
+	paramType := "StringList"
+	overwriteFalse := false
+
+	tests := []struct {
+		name          string
+		ssmClient     *stubSSMClient
+		values        []string
+		expectedError error
+		expectedInput *ssm.PutParameterInput
+	}{
+		{
+			name:      "Set Joined Values",
+			ssmClient: &stubSSMClient{},
+			values:    []string{"a", "b", "c"},
+			expectedInput: &ssm.PutParameterInput{
+				Name:      &paramName,
+				Type:      &paramType,
+				Value:     &paramValue,
+				Overwrite: &overwriteFalse,
+			},
+		},
+		{
+			name:          "Failed Value With Comma",
+			ssmClient:     &stubSSMClient{},
+			values:        []string{"a", "b,c"},
+			expectedError: ErrParameterInvalidValue,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewParameterStoreWithClient(test.ssmClient)
+			err := client.PutStringListParameter(paramName, test.values, false)
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if !reflect.DeepEqual(test.ssmClient.PutParameterInputReceived, test.expectedInput) {
+				t.Errorf(`Unexpected parameter: got %v, expected %v`, test.ssmClient.PutParameterInputReceived, test.expectedInput)
+			}
+		})
+	}
+}
+
+func TestParameterStore_PutParameterWithOptions(t *testing.T) {
+	stub := &stubSSMClient{}
+	client := NewParameterStoreWithClient(stub)
+	err := client.PutParameterWithOptions("foo", "baz", PutParameterOptions{
+		Description:    "description",
+		Tier:           ssm.ParameterTierAdvanced,
+		AllowedPattern: "^[a-z]+$",
+		DataType:       "text",
+		Overwrite:      true,
+	})
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expectedInput := &ssm.PutParameterInput{
+		Name:           aws.String("foo"),
+		Type:           aws.String(ParameterTypeString),
+		Value:          aws.String("baz"),
+		Description:    aws.String("description"),
+		Tier:           aws.String(ssm.ParameterTierAdvanced),
+		AllowedPattern: aws.String("^[a-z]+$"),
+		DataType:       aws.String("text"),
+		Overwrite:      aws.Bool(true),
+	}
+	if !reflect.DeepEqual(stub.PutParameterInputReceived, expectedInput) {
+		t.Errorf(`Unexpected parameter: got %v, expected %v`, stub.PutParameterInputReceived, expectedInput)
+	}
+}
+
+func TestParameterStore_PutParameterAlreadyExists(t *testing.T) {
+	client := NewParameterStoreWithClient(&stubSSMClient{
+		PutParameterError: awserr.New(ssm.ErrCodeParameterAlreadyExists, "parameter already exists", nil),
+	})
+	if err := client.PutParameter("foo", "baz", false); err != ErrParameterInvalidName {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterInvalidName)
+	}
+}
+
+func TestParameterStore_DeleteParameter(t *testing.T) {
+	tests := []struct {
+		name          string
+		ssmClient     ssmClient
+		parameterName string
+		expectedError error
+	}{
+		{
+			name:          "Success",
+			ssmClient:     &stubSSMClient{},
+			parameterName: "/my-service/dev/DB_PASSWORD",
+		},
+		{
+			name:          "Failed Empty name",
+			ssmClient:     &stubSSMClient{},
+			parameterName: "",
+			expectedError: ErrParameterInvalidName,
+		},
+		{
+			name: "Failed Parameter Not Found",
+			ssmClient: &stubSSMClient{
+				DeleteParameterError: awserr.New(ssm.ErrCodeParameterNotFound, "parameter not found", nil),
+			},
+			parameterName: "/my-service/dev/NOT_FOUND",
+			expectedError: ErrParameterNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewParameterStoreWithClient(test.ssmClient)
+			err := client.DeleteParameter(test.parameterName)
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestParameterStore_DeleteParameters(t *testing.T) {
+	values := make(map[string]bool)
+	var names []string
+	for i := 0; i < 15; i++ {
+		name := fmt.Sprintf("/my-service/dev/param-%d", i)
+		values[name] = true
+		names = append(names, name)
+	}
+	names = append(names, "/my-service/dev/NOT_FOUND")
+
+	stub := &stubSSMClient{DeleteParametersValues: values}
+	client := NewParameterStoreWithClient(stub)
+	invalidNames, err := client.DeleteParameters(names)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if !reflect.DeepEqual(invalidNames, []string{"/my-service/dev/NOT_FOUND"}) {
+		t.Errorf(`Unexpected invalid names: got %v`, invalidNames)
+	}
+	if len(stub.DeleteParametersInputsReceived) != 2 {
+		t.Errorf(`Unexpected requests: got %d, expected %d`, len(stub.DeleteParametersInputsReceived), 2)
+	}
+
+	if _, err := client.DeleteParameters([]string{""}); err != ErrParameterInvalidName {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterInvalidName)
+	}
+}
+
+func TestParameterStore_GetParameterHistory(t *testing.T) {
+	modified := time.Date(2019, 11, 4, 10, 30, 0, 0, time.UTC)
+	version1 := new(ssm.ParameterHistory).
+		SetName("/my-service/dev/DB_PASSWORD").
+		SetValue("first").
+		SetVersion(1).
+		SetLastModifiedUser("arn:aws:iam::aws-account-id:user/alice").
+		SetLastModifiedDate(modified)
+	version2 := new(ssm.ParameterHistory).
+		SetName("/my-service/dev/DB_PASSWORD").
+		SetValue("second").
+		SetVersion(2).
+		SetLabels(aws.StringSlice([]string{"prod-approved"})).
+		SetDescription("rotated").
+		SetPolicies([]*ssm.ParameterInlinePolicy{
+			new(ssm.ParameterInlinePolicy).
+				SetPolicyText(`{"Type":"Expiration"}`).
+				SetPolicyType("Expiration").
+				SetPolicyStatus("Pending"),
+		})
+
+	tests := []struct {
+		name           string
+		ssmClient      ssmClient
+		parameterName  string
+		expectedError  error
+		expectedOutput []ParameterHistory
+	}{
+		{
+			name: "Success",
+			ssmClient: &stubSSMClient{
+				GetParameterHistoryOutput: []ssm.GetParameterHistoryOutput{
+					{Parameters: []*ssm.ParameterHistory{version2}},
+					{Parameters: []*ssm.ParameterHistory{version1}},
+				},
+			},
+			parameterName: "/my-service/dev/DB_PASSWORD",
+			expectedOutput: []ParameterHistory{
+				{
+					Name:             "/my-service/dev/DB_PASSWORD",
+					Value:            "first",
+					Version:          1,
+					LastModifiedUser: "arn:aws:iam::aws-account-id:user/alice",
+					LastModifiedDate: modified,
+					Labels:           []string{},
+				},
+				{
+					Name:        "/my-service/dev/DB_PASSWORD",
+					Value:       "second",
+					Version:     2,
+					Labels:      []string{"prod-approved"},
+					Description: "rotated",
+					Policies: []ParameterPolicy{
+						{Text: `{"Type":"Expiration"}`, Type: "Expiration", Status: "Pending"},
+					},
+				},
+			},
+		},
+		{
+			name:          "Failed Empty name",
+			ssmClient:     &stubSSMClient{},
+			parameterName: "",
+			expectedError: ErrParameterInvalidName,
+		},
+		{
+			name: "Failed Parameter Not Found",
+			ssmClient: &stubSSMClient{
+				GetParameterHistoryError: awserr.New(ssm.ErrCodeParameterNotFound, "parameter not found", nil),
+			},
+			parameterName: "/my-service/dev/NOT_FOUND",
+			expectedError: ErrParameterNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewParameterStoreWithClient(test.ssmClient)
+			history, err := client.GetParameterHistory(test.parameterName, true)
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if !reflect.DeepEqual(history, test.expectedOutput) {
+				t.Errorf(`Unexpected history: got %+v, expected %+v`, history, test.expectedOutput)
+			}
+		})
+	}
+}
+
+func TestParameterStore_GetParameterWithSelector(t *testing.T) {
+	output := &ssm.GetParameterOutput{
+		Parameter: new(ssm.Parameter).
+			SetName("/my-service/dev/DB_PASSWORD").
+			SetValue("something-secure").
+			SetVersion(3).
+			SetSelector(":prod-approved"),
+	}
+	tests := []struct {
+		name          string
+		get           func(client *ParameterStore) (*Parameter, error)
+		expectedError error
+		expectedName  string
+	}{
+		{
+			name: "Success Version",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterVersion("/my-service/dev/DB_PASSWORD", 3, true)
+			},
+			expectedName: "/my-service/dev/DB_PASSWORD:3",
+		},
+		{
+			name: "Success Label",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD", "prod-approved", true)
+			},
+			expectedName: "/my-service/dev/DB_PASSWORD:prod-approved",
+		},
+		{
+			name: "Failed Empty name",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterVersion("", 3, true)
+			},
+			expectedError: ErrParameterInvalidName,
+		},
+		{
+			name: "Failed Name With Selector",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD:2", "prod-approved", true)
+			},
+			expectedError: ErrParameterInvalidName,
+		},
+		{
+			name: "Failed Invalid Version",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterVersion("/my-service/dev/DB_PASSWORD", 0, true)
+			},
+			expectedError: ErrParameterInvalidSelector,
+		},
+		{
+			name: "Failed Empty Label",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD", "", true)
+			},
+			expectedError: ErrParameterInvalidSelector,
+		},
+		{
+			name: "Failed Numeric Label",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD", "3", true)
+			},
+			expectedError: ErrParameterInvalidSelector,
+		},
+		{
+			name: "Failed Label Beginning With A Number",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD", "2024-release", true)
+			},
+			expectedError: ErrParameterInvalidSelector,
+		},
+		{
+			name: "Failed Label Beginning With aws",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD", "AWS-approved", true)
+			},
+			expectedError: ErrParameterInvalidSelector,
+		},
+		{
+			name: "Failed Label Beginning With ssm",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD", "ssm-approved", true)
+			},
+			expectedError: ErrParameterInvalidSelector,
+		},
+		{
+			name: "Failed Label With Colon",
+			get: func(client *ParameterStore) (*Parameter, error) {
+				return client.GetParameterByLabel("/my-service/dev/DB_PASSWORD", "prod:approved", true)
+			},
+			expectedError: ErrParameterInvalidSelector,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := &stubSSMClient{GetParameterOutput: output}
+			parameter, err := test.get(NewParameterStoreWithClient(stub))
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if err != nil {
+				return
+			}
+			if name := aws.StringValue(stub.GetParameterInputReceived.Name); name != test.expectedName {
+				t.Errorf(`Unexpected name: got %s, expected %s`, name, test.expectedName)
+			}
+			if parameter.Version != 3 || parameter.Selector != ":prod-approved" {
+				t.Errorf(`Unexpected parameter: got %+v`, parameter)
+			}
+		})
+	}
+}
+
+func TestParameterStore_LabelParameterVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		ssmClient     *stubSSMClient
+		parameterName string
+		version       int64
+		labels        []string
+		expectedError error
+		expectedInput *ssm.LabelParameterVersionInput
+	}{
+		{
+			name:          "Success",
+			ssmClient:     &stubSSMClient{},
+			parameterName: "/my-service/dev/DB_PASSWORD",
+			version:       3,
+			labels:        []string{"prod-approved"},
+			expectedInput: &ssm.LabelParameterVersionInput{
+				Name:             aws.String("/my-service/dev/DB_PASSWORD"),
+				ParameterVersion: aws.Int64(3),
+				Labels:           aws.StringSlice([]string{"prod-approved"}),
+			},
+		},
+		{
+			name:          "Success Latest Version",
+			ssmClient:     &stubSSMClient{},
+			parameterName: "/my-service/dev/DB_PASSWORD",
+			labels:        []string{"prod-approved"},
+			expectedInput: &ssm.LabelParameterVersionInput{
+				Name:   aws.String("/my-service/dev/DB_PASSWORD"),
+				Labels: aws.StringSlice([]string{"prod-approved"}),
+			},
+		},
+		{
+			name:          "Failed Empty name",
+			ssmClient:     &stubSSMClient{},
+			labels:        []string{"prod-approved"},
+			expectedError: ErrParameterInvalidName,
+		},
+		{
+			name:          "Failed No Labels",
+			ssmClient:     &stubSSMClient{},
+			parameterName: "/my-service/dev/DB_PASSWORD",
+			expectedError: ErrParameterInvalidSelector,
+		},
+		{
+			name: "Failed Parameter Not Found",
+			ssmClient: &stubSSMClient{
+				LabelParameterVersionError: awserr.New(ssm.ErrCodeParameterNotFound, "parameter not found", nil),
+			},
+			parameterName: "/my-service/dev/NOT_FOUND",
+			labels:        []string{"prod-approved"},
+			expectedError: ErrParameterNotFound,
+			expectedInput: &ssm.LabelParameterVersionInput{
+				Name:   aws.String("/my-service/dev/NOT_FOUND"),
+				Labels: aws.StringSlice([]string{"prod-approved"}),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewParameterStoreWithClient(test.ssmClient)
+			err := client.LabelParameterVersion(test.parameterName, test.version, test.labels)
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if !reflect.DeepEqual(test.ssmClient.LabelParameterVersionInputReceived, test.expectedInput) {
+				t.Errorf(`Unexpected input: got %v, expected %v`, test.ssmClient.LabelParameterVersionInputReceived, test.expectedInput)
+			}
+		})
+	}
+}
+
+func TestParameterStore_InvalidLabels(t *testing.T) {
+	client := NewParameterStoreWithClient(&stubSSMClient{InvalidLabels: []string{"aws-reserved"}})
+	expected := &InvalidLabelsError{Name: "/my-service/dev/DB_PASSWORD", Labels: []string{"aws-reserved"}}
+
+	err := client.LabelParameterVersion("/my-service/dev/DB_PASSWORD", 3, []string{"prod-approved", "aws-reserved"})
+	var labelsErr *InvalidLabelsError
+	if !errors.As(err, &labelsErr) || !reflect.DeepEqual(labelsErr, expected) {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, expected)
+	}
+
+	err = client.UnlabelParameterVersion("/my-service/dev/DB_PASSWORD", 3, []string{"prod-approved", "aws-reserved"})
+	if !errors.As(err, &labelsErr) || !reflect.DeepEqual(labelsErr, expected) {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, expected)
+	}
+
+	if err := client.UnlabelParameterVersion("/my-service/dev/DB_PASSWORD", 0, []string{"prod-approved"}); err != ErrParameterInvalidSelector {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterInvalidSelector)
+	}
+}
+
+func TestParameterStore_DescribeParameters(t *testing.T) {
+	metadata1 := new(ssm.ParameterMetadata).
+		SetName("/my-service/dev/DB_PASSWORD").
+		SetType(ssm.ParameterTypeSecureString).
+		SetKeyId("alias/aws/ssm").
+		SetVersion(2)
+	metadata2 := new(ssm.ParameterMetadata).
+		SetName("/my-service/dev/DB_HOST").
+		SetType(ssm.ParameterTypeString).
+		SetTier(ssm.ParameterTierStandard).
+		SetVersion(1)
+
+	tests := []struct {
+		name           string
+		ssmClient      *stubSSMClient
+		filters        []ParameterFilter
+		expectedError  error
+		expectedOutput []ParameterMetadata
+	}{
+		{
+			name: "Success",
+			ssmClient: &stubSSMClient{
+				DescribeParametersOutput: []ssm.DescribeParametersOutput{
+					{Parameters: []*ssm.ParameterMetadata{metadata1}},
+					{Parameters: []*ssm.ParameterMetadata{metadata2}},
+				},
+			},
+			filters: []ParameterFilter{FilterNameBeginsWith("/my-service/"), FilterTag("owner", "payments")},
+			expectedOutput: []ParameterMetadata{
+				{Name: "/my-service/dev/DB_PASSWORD", Type: "SecureString", KeyID: "alias/aws/ssm", Version: 2},
+				{Name: "/my-service/dev/DB_HOST", Type: "String", Tier: "Standard", Version: 1},
+			},
+		},
+		{
+			name: "Failed SSM Request Error",
+			ssmClient: &stubSSMClient{
+				DescribeParametersError: errSSM,
+			},
+			expectedError: errSSM,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewParameterStoreWithClient(test.ssmClient)
+			metadata, err := client.DescribeParameters(test.filters...)
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if !reflect.DeepEqual(metadata, test.expectedOutput) {
+				t.Errorf(`Unexpected metadata: got %+v, expected %+v`, metadata, test.expectedOutput)
+			}
+			if len(test.ssmClient.DescribeParametersInput.ParameterFilters) != len(test.filters) {
+				t.Errorf(`Unexpected filters: got %v, expected %v`, test.ssmClient.DescribeParametersInput.ParameterFilters, test.filters)
+			}
+		})
+	}
+}
+
+func TestParameterStore_DescribeParametersInvalidFilter(t *testing.T) {
+	stub := &stubSSMClient{}
+	metadata, err := NewParameterStoreWithClient(stub).DescribeParameters(FilterNameBeginsWith("/my-service/"), FilterLabel("prod-approved"))
+	if err != ErrParameterInvalidFilter {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterInvalidFilter)
+	}
+	if metadata != nil {
+		t.Errorf(`Unexpected metadata: %+v`, metadata)
+	}
+	if stub.DescribeParametersInput != nil {
+		t.Errorf(`Unexpected request: %v`, stub.DescribeParametersInput)
+	}
+}
+
+func TestParameterStore_GetAllParametersByPathWithFilters(t *testing.T) {
+	tests := []struct {
+		name          string
+		filters       []ParameterFilter
+		expectedError error
+	}{
+		{
+			name:    "Label And Type",
+			filters: []ParameterFilter{FilterLabel("prod-approved"), FilterType(ParameterTypeSecureString)},
+		},
+		{
+			name:          "Unsupported Filter",
+			filters:       []ParameterFilter{FilterLabel("prod-approved"), FilterTag("owner")},
+			expectedError: ErrParameterInvalidFilter,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := &stubSSMClient{GetParametersByPathOutput: stubPathOutput(param1)}
+			parameters, err := NewParameterStoreWithClient(stub).GetAllParametersByPathWithFilters("/my-service/dev/", true, test.filters...)
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if err != nil {
+				if stub.GetParametersByPathInput != nil {
+					t.Errorf(`Unexpected request: %v`, stub.GetParametersByPathInput)
+				}
+				return
+			}
+			if value := parameters.GetValueByName("DB_PASSWORD"); value != "something-secure" {
+				t.Errorf(`Unexpected value: got %s, expected %s`, value, "something-secure")
+			}
+			filters := stub.GetParametersByPathInput.ParameterFilters
+			if len(filters) != len(test.filters) || *filters[0].Key != "Label" {
+				t.Errorf(`Unexpected filters: got %v, expected %v`, filters, test.filters)
+			}
+		})
+	}
+}
+
+func TestParameterStore_PutParameterWithTags(t *testing.T) {
+	tags := map[string]string{"owner": "payments", "cost-center": "platform"}
+	expectedTags := []*ssm.Tag{
+		new(ssm.Tag).SetKey("cost-center").SetValue("platform"),
+		new(ssm.Tag).SetKey("owner").SetValue("payments"),
+	}
+
+	stub := &stubSSMClient{}
+	client := NewParameterStoreWithClient(stub)
+	if err := client.PutParameterWithOptions("foo", "baz", PutParameterOptions{Tags: tags}); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if !reflect.DeepEqual(stub.PutParameterInputReceived.Tags, expectedTags) {
+		t.Errorf(`Unexpected tags: got %v, expected %v`, stub.PutParameterInputReceived.Tags, expectedTags)
+	}
+	if stub.AddTagsInputReceived != nil {
+		t.Errorf(`Unexpected AddTagsToResource request: %v`, stub.AddTagsInputReceived)
+	}
+
+	stub = &stubSSMClient{}
+	client = NewParameterStoreWithClient(stub)
+	if err := client.PutParameterWithOptions("foo", "baz", PutParameterOptions{Tags: tags, Overwrite: true}); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if stub.PutParameterInputReceived.Tags != nil {
+		t.Errorf(`Unexpected tags when overwriting: %v`, stub.PutParameterInputReceived.Tags)
+	}
+	if stub.AddTagsInputReceived == nil || !reflect.DeepEqual(stub.AddTagsInputReceived.Tags, expectedTags) {
+		t.Errorf(`Unexpected AddTagsToResource request: %v`, stub.AddTagsInputReceived)
+	}
+}
+
+func TestParameterStore_Tags(t *testing.T) {
+	stub := &stubSSMClient{
+		ListTagsOutput: &ssm.ListTagsForResourceOutput{
+			TagList: []*ssm.Tag{new(ssm.Tag).SetKey("owner").SetValue("payments")},
+		},
+	}
+	client := NewParameterStoreWithClient(stub)
+
+	if err := client.AddTags("/my-service/dev/DB_PASSWORD", map[string]string{"owner": "payments"}); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expectedAdd := &ssm.AddTagsToResourceInput{
+		ResourceId:   aws.String("/my-service/dev/DB_PASSWORD"),
+		ResourceType: aws.String("Parameter"),
+		Tags:         []*ssm.Tag{new(ssm.Tag).SetKey("owner").SetValue("payments")},
+	}
+	if !reflect.DeepEqual(stub.AddTagsInputReceived, expectedAdd) {
+		t.Errorf(`Unexpected input: got %v, expected %v`, stub.AddTagsInputReceived, expectedAdd)
+	}
+
+	if err := client.RemoveTags("/my-service/dev/DB_PASSWORD", []string{"owner"}); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expectedRemove := &ssm.RemoveTagsFromResourceInput{
+		ResourceId:   aws.String("/my-service/dev/DB_PASSWORD"),
+		ResourceType: aws.String("Parameter"),
+		TagKeys:      aws.StringSlice([]string{"owner"}),
+	}
+	if !reflect.DeepEqual(stub.RemoveTagsInputReceived, expectedRemove) {
+		t.Errorf(`Unexpected input: got %v, expected %v`, stub.RemoveTagsInputReceived, expectedRemove)
+	}
+
+	tags, err := client.ListTags("/my-service/dev/DB_PASSWORD")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if !reflect.DeepEqual(tags, map[string]string{"owner": "payments"}) {
+		t.Errorf(`Unexpected tags: %v`, tags)
+	}
+
+	client = NewParameterStoreWithClient(&stubSSMClient{
+		TagsError: awserr.New(ssm.ErrCodeInvalidResourceId, "invalid resource id", nil),
+	})
+	if _, err := client.ListTags("/my-service/dev/NOT_FOUND"); err != ErrParameterNotFound {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterNotFound)
+	}
+	if err := client.AddTags("", map[string]string{"owner": "payments"}); err != ErrParameterInvalidName {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterInvalidName)
+	}
+}
+
+func TestParameterStore_PutParameterWithPolicies(t *testing.T) {
+	stub := &stubSSMClient{}
+	client := NewParameterStoreWithClient(stub)
+	err := client.PutParameterWithOptions("foo", "baz", PutParameterOptions{
+		Tier:     ssm.ParameterTierAdvanced,
+		Policies: []Policy{NoChangeNotificationPolicy(20, PolicyUnitDays)},
+	})
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	expected := `[{"Type":"NoChangeNotification","Version":"1.0","Attributes":{"After":"20","Unit":"Days"}}]`
+	if policies := aws.StringValue(stub.PutParameterInputReceived.Policies); policies != expected {
+		t.Errorf(`Unexpected policies: got %s, expected %s`, policies, expected)
+	}
+}
+
+func TestParameterStore_PutParameterTier(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		tier          string
+		expectedError error
+		expectedTier  *string
+	}{
+		{
+			name:  "Small Value Default Tier",
+			value: "baz",
+		},
+		{
+			name:         "Small Value Advanced Tier",
+			value:        "baz",
+			tier:         ParameterTierAdvanced,
+			expectedTier: aws.String("Advanced"),
+		},
+		{
+			name:         "Large Value Intelligent-Tiering",
+			value:        strings.Repeat("a", 5*1024),
+			expectedTier: aws.String("Intelligent-Tiering"),
+		},
+		{
+			name:         "Large Value Explicit Tier",
+			value:        strings.Repeat("a", 5*1024),
+			tier:         ParameterTierAdvanced,
+			expectedTier: aws.String("Advanced"),
+		},
+		{
+			name:          "Failed Value Too Large",
+			value:         strings.Repeat("a", 8*1024+1),
+			tier:          ParameterTierAdvanced,
+			expectedError: ErrParameterTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := &stubSSMClient{}
+			client := NewParameterStoreWithClient(stub)
+			err := client.PutParameterWithOptions("foo", test.value, PutParameterOptions{Tier: test.tier})
+			if err != test.expectedError {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if err != nil {
+				if stub.PutParameterInputReceived != nil {
+					t.Errorf(`Unexpected request: %v`, stub.PutParameterInputReceived)
+				}
+				return
+			}
+			if !reflect.DeepEqual(stub.PutParameterInputReceived.Tier, test.expectedTier) {
+				t.Errorf(`Unexpected tier: got %v, expected %v`, stub.PutParameterInputReceived.Tier, test.expectedTier)
+			}
+		})
+	}
+}
+
+// pathsSSMClient serves the parameters of each path and tracks how many paths are requested at the same time
+type pathsSSMClient struct {
+	stubSSMClient
+	parameters map[string][]*ssm.Parameter
+	errors     map[string]error
+	// cancel is called by the first request, which then waits for the cancellation to be seen
+	cancel context.CancelFunc
+
+	mu          sync.Mutex
+	requests    int
+	recursive   bool
+	inFlight    int
+	maxInFlight int
+}
+
+func (s *pathsSSMClient) GetParametersByPathPagesWithContext(ctx aws.Context, input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool, opts ...request.Option) error {
+	s.mu.Lock()
+	s.requests++
+	s.recursive = s.recursive || *input.Recursive
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	if s.cancel != nil {
+		s.cancel()
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(time.Millisecond)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.errors[*input.Path]; err != nil {
+		return err
+	}
+	fn(&ssm.GetParametersByPathOutput{Parameters: s.parameters[*input.Path]}, true)
+	return nil
+}
+
+func TestParameterStore_GetAllParametersByPaths(t *testing.T) {
+	client := &pathsSSMClient{
+		parameters: make(map[string][]*ssm.Parameter),
+		errors:     map[string]error{"/failing/": errSSM},
+	}
+	var paths []string
+	for i := 0; i < 10; i++ {
+		path := fmt.Sprintf("/service-%d/", i)
+		client.parameters[path] = []*ssm.Parameter{
+			new(ssm.Parameter).SetName(path + "param").SetValue(fmt.Sprint(i)),
+		}
+		paths = append(paths, path)
+	}
+	paths = append(paths, "/failing/")
+
+	results, err := NewParameterStoreWithClient(client).GetAllParametersByPaths(paths, true)
+	if !errors.Is(err, errSSM) || !strings.Contains(err.Error(), "/failing/") {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, errSSM)
+	}
+	if len(results) != len(paths) {
+		t.Fatalf(`Unexpected results: got %d, expected %d`, len(results), len(paths))
+	}
+	for i, path := range paths[:10] {
+		if value := results[i].GetValueByName("param"); value != fmt.Sprint(i) {
+			t.Errorf(`Unexpected value for %s: got %s, expected %d`, path, value, i)
+		}
+	}
+	if results[10] != nil {
+		t.Errorf(`Unexpected parameters for a failed path: %+v`, results[10])
+	}
+	if client.maxInFlight > defaultPathsWorkers {
+		t.Errorf(`Unexpected concurrent requests: got %d, expected at most %d`, client.maxInFlight, defaultPathsWorkers)
+	}
+}
+
+func TestParameterStore_GetAllParametersByPathsWithOptions(t *testing.T) {
+	client := &pathsSSMClient{parameters: make(map[string][]*ssm.Parameter)}
+	var paths []string
+	for i := 0; i < 10; i++ {
+		path := fmt.Sprintf("/service-%d/", i)
+		client.parameters[path] = []*ssm.Parameter{
+			new(ssm.Parameter).SetName(path + "db/host").SetValue(fmt.Sprint(i)),
+		}
+		paths = append(paths, path)
+	}
+
+	results, err := NewParameterStoreWithClient(client).GetAllParametersByPathsWithOptions(paths, GetAllParametersByPathsOptions{
+		Recursive: true,
+		Workers:   2,
+	})
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	for i, path := range paths {
+		if value := results[i].GetValueByName("db/host"); value != fmt.Sprint(i) {
+			t.Errorf(`Unexpected value for %s: got %s, expected %d`, path, value, i)
+		}
+	}
+	if !client.recursive {
+		t.Error(`Expected the paths to be requested recursively`)
+	}
+	if client.maxInFlight > 2 {
+		t.Errorf(`Unexpected concurrent requests: got %d, expected at most %d`, client.maxInFlight, 2)
+	}
+}
+
+func TestParameterStore_GetAllParametersByPathsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &pathsSSMClient{cancel: cancel}
+	paths := []string{"/service-0/", "/service-1/", "/service-2/"}
+
+	results, err := NewParameterStoreWithClient(client).GetAllParametersByPathsWithOptionsWithContext(ctx, paths, GetAllParametersByPathsOptions{
+		Workers: 1,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, context.Canceled)
+	}
+	for _, path := range paths {
+		if !strings.Contains(err.Error(), path) {
+			t.Errorf(`Expected the error to report %s: %v`, path, err)
+		}
+	}
+	for i, parameters := range results {
+		if parameters != nil {
+			t.Errorf(`Unexpected parameters for %s: %+v`, paths[i], parameters)
+		}
+	}
+	if client.requests != 1 {
+		t.Errorf(`Unexpected requests after the cancellation: got %d, expected %d`, client.requests, 1)
+	}
+}
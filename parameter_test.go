@@ -2,10 +2,14 @@ package awsssm
 
 import (
 	"bytes"
+	"errors"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
 )
 
 type env struct {
@@ -82,6 +86,71 @@ func TestParameters_GetValueByFullPath(t *testing.T) {
 	}
 }
 
+func TestParameters_Lookup(t *testing.T) {
+	parameters := NewParameters("/my-service/dev/", map[string]*Parameter{
+		"/my-service/dev/DB_PASSWORD": {Value: param1.Value},
+		"/my-service/dev/EMPTY":       {Value: aws.String("")},
+	})
+	tests := []struct {
+		name          string
+		paramName     string
+		expectedValue string
+		expectedFound bool
+	}{
+		{
+			name:          "Existing Parameter",
+			paramName:     "DB_PASSWORD",
+			expectedValue: "something-secure",
+			expectedFound: true,
+		},
+		{
+			name:          "Empty Parameter",
+			paramName:     "EMPTY",
+			expectedValue: "",
+			expectedFound: true,
+		},
+		{
+			name:          "Missing Parameter",
+			paramName:     "NOT_EXISTING_PARAMETER",
+			expectedValue: "",
+			expectedFound: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, found := parameters.LookupByName(test.paramName)
+			if value != test.expectedValue || found != test.expectedFound {
+				t.Errorf(`Unexpected lookup by name: got %q, %t, expected %q, %t`, value, found, test.expectedValue, test.expectedFound)
+			}
+			value, found = parameters.LookupByFullPath("/my-service/dev/" + test.paramName)
+			if value != test.expectedValue || found != test.expectedFound {
+				t.Errorf(`Unexpected lookup by full path: got %q, %t, expected %q, %t`, value, found, test.expectedValue, test.expectedFound)
+			}
+
+			value, err := parameters.MustGetByName(test.paramName)
+			if value != test.expectedValue {
+				t.Errorf(`Unexpected value: got %q, expected %q`, value, test.expectedValue)
+			}
+			if test.expectedFound {
+				if err != nil {
+					t.Errorf(`Unexpected error: %s`, err)
+				}
+				return
+			}
+			var missingErr *MissingParameterError
+			if !errors.As(err, &missingErr) || missingErr.FullPath != "/my-service/dev/"+test.paramName {
+				t.Errorf(`Unexpected error: got %v, expected a missing parameter error`, err)
+			}
+			if !errors.Is(err, ErrParameterNotFound) {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterNotFound)
+			}
+			if _, err := parameters.MustGetByFullPath("/my-service/dev/" + test.paramName); !errors.As(err, &missingErr) {
+				t.Errorf(`Unexpected error: got %v, expected a missing parameter error`, err)
+			}
+		})
+	}
+}
+
 func TestParameters_Decode(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -116,6 +185,161 @@ func TestParameters_Decode(t *testing.T) {
 	}
 }
 
+func TestParameters_DecodeStrict(t *testing.T) {
+	tests := []struct {
+		name            string
+		parameters      map[string]*Parameter
+		output          interface{}
+		expectedError   *StrictDecodeError
+		expectedMessage string
+	}{
+		{
+			name:       "Unset Field",
+			parameters: getParametersMap(),
+			output:     new(env),
+			expectedError: &StrictDecodeError{
+				Unset: []string{"NOT_THERE"},
+			},
+		},
+		{
+			name: "Unused Parameters",
+			parameters: map[string]*Parameter{
+				"/my-service/dev/DB_PASSWORD": {Value: param1.Value},
+				"/my-service/dev/DB_HOST":     {Value: param2.Value},
+				"/my-service/dev/NOT_THERE":   {Value: aws.String("")},
+				"/my-service/dev/DB_PASWORD":  {Value: param1.Value},
+				"/my-service/dev/DB_USERNAME": {Value: param3.Value},
+			},
+			output: new(env),
+			expectedError: &StrictDecodeError{
+				Unused: []string{"/my-service/dev/DB_PASWORD", "/my-service/dev/DB_USERNAME"},
+			},
+		},
+		{
+			name: "Nested Unused And Unset",
+			parameters: map[string]*Parameter{
+				"/my-service/dev/db/host":    {Value: param2.Value},
+				"/my-service/dev/db/port":    {Value: aws.String("5432")},
+				"/my-service/dev/db/ssl":     {Value: aws.String("true")},
+				"/my-service/dev/db/timeout": {Value: aws.String("5s")},
+				"/my-service/dev/db/user":    {Value: aws.String("username")},
+			},
+			output: new(nestedEnv),
+			expectedError: &StrictDecodeError{
+				Unused: []string{"/my-service/dev/db/user"},
+				Unset:  []string{"Name"},
+			},
+		},
+		{
+			name: "Nested Typo",
+			parameters: map[string]*Parameter{
+				"/my-service/dev/db/hots":    {Value: param2.Value},
+				"/my-service/dev/db/port":    {Value: aws.String("5432")},
+				"/my-service/dev/db/ssl":     {Value: aws.String("true")},
+				"/my-service/dev/db/timeout": {Value: aws.String("5s")},
+				"/my-service/dev/name":       {Value: aws.String("my-service")},
+				"/my-service/dev/cache/host": {Value: aws.String("cache.something.aws.com")},
+				"/my-service/dev/cache/port": {Value: aws.String("6379")},
+			},
+			output: new(nestedEnv),
+			expectedError: &StrictDecodeError{
+				Unused: []string{"/my-service/dev/cache/host", "/my-service/dev/cache/port", "/my-service/dev/db/hots"},
+				Unset:  []string{"DB.Host"},
+			},
+			expectedMessage: "strict decoding failed, unused parameters: /my-service/dev/cache/host, /my-service/dev/cache/port, /my-service/dev/db/hots; unset fields: DB.Host",
+		},
+		{
+			name: "Everything Matches",
+			parameters: map[string]*Parameter{
+				"/my-service/dev/DB_PASSWORD": {Value: param1.Value},
+				"/my-service/dev/DB_HOST":     {Value: param2.Value},
+				"/my-service/dev/NOT_THERE":   {Value: aws.String("")},
+			},
+			output: new(env),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewParameters("/my-service/dev/", test.parameters).DecodeStrict(test.output)
+			if test.expectedError == nil {
+				if err != nil {
+					t.Errorf(`Unexpected error: %s`, err)
+				}
+				return
+			}
+			var strictErr *StrictDecodeError
+			if !errors.As(err, &strictErr) || !reflect.DeepEqual(strictErr, test.expectedError) {
+				t.Errorf(`Unexpected error: got %v, expected %v`, err, test.expectedError)
+			}
+			if test.expectedMessage != "" && err.Error() != test.expectedMessage {
+				t.Errorf(`Unexpected message: got %s, expected %s`, err, test.expectedMessage)
+			}
+		})
+	}
+}
+
+type nestedEnv struct {
+	DB struct {
+		Host    string
+		Port    int
+		SSL     bool
+		Timeout time.Duration
+	}
+	Name string
+}
+
+func TestParameters_DecodeNested(t *testing.T) {
+	parameters := map[string]*Parameter{
+		"/my-service/dev/db/host":    {Value: param2.Value},
+		"/my-service/dev/db/port":    {Value: aws.String("5432")},
+		"/my-service/dev/db/ssl":     {Value: aws.String("true")},
+		"/my-service/dev/db/timeout": {Value: aws.String("5s")},
+		"/my-service/dev/name":       {Value: aws.String("my-service")},
+	}
+	expected := &nestedEnv{Name: "my-service"}
+	expected.DB.Host = "rds.something.aws.com"
+	expected.DB.Port = 5432
+	expected.DB.SSL = true
+	expected.DB.Timeout = 5 * time.Second
+
+	e := new(nestedEnv)
+	err := NewParameters("/my-service/dev/", parameters).Decode(e)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if !reflect.DeepEqual(e, expected) {
+		t.Errorf(`Unexpected value: got %+v, expected %+v`, e, expected)
+	}
+}
+
+func TestParameters_DecodeWithoutBasePath(t *testing.T) {
+	var output struct {
+		Host     string `mapstructure:"/my-service/dev/DB_HOST"`
+		Password string `mapstructure:"/shared/dev/DB_PASSWORD"`
+	}
+	parameters := map[string]*Parameter{
+		"/my-service/dev/DB_HOST": {Value: param2.Value},
+		"/shared/dev/DB_PASSWORD": {Value: param1.Value},
+	}
+	if err := NewParameters("", parameters).Decode(&output); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if output.Host != "rds.something.aws.com" || output.Password != "something-secure" {
+		t.Errorf(`Unexpected value: %+v`, output)
+	}
+}
+
+func TestParameters_DecodeConflictingPaths(t *testing.T) {
+	parameters := map[string]*Parameter{
+		"/my-service/dev/db":      {Value: aws.String("db")},
+		"/my-service/dev/db/host": {Value: param2.Value},
+	}
+	err := NewParameters("/my-service/dev/", parameters).Decode(new(nestedEnv))
+	if err == nil {
+		t.Error(`Expected an error for conflicting paths`)
+	}
+}
+
 func TestParameters_Read(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -183,6 +407,68 @@ func TestParameters_GetAllValues(t *testing.T) {
 	}
 }
 
+func TestParameters_TypedGetters(t *testing.T) {
+	parameters := NewParameters("/my-service/dev/", map[string]*Parameter{
+		"/my-service/dev/PORT":       {Value: aws.String("5432")},
+		"/my-service/dev/DEBUG":      {Value: aws.String("true")},
+		"/my-service/dev/RATIO":      {Value: aws.String("0.25")},
+		"/my-service/dev/TIMEOUT":    {Value: aws.String("1m30s")},
+		"/my-service/dev/RELEASED":   {Value: aws.String("2019-11-04T10:30:00Z")},
+		"/my-service/dev/HOSTS":      {Value: aws.String("a.aws.com,b.aws.com")},
+		"/my-service/dev/EMPTY":      {Value: aws.String("")},
+		"/my-service/dev/LIMITS":     {Value: aws.String(`{"max":10}`)},
+		"/my-service/dev/NOT_NUMBER": {Value: aws.String("something-secure")},
+	})
+
+	if value, err := parameters.GetInt("PORT"); err != nil || value != 5432 {
+		t.Errorf(`Unexpected int: got %d, %v`, value, err)
+	}
+	if value, err := parameters.GetBool("DEBUG"); err != nil || !value {
+		t.Errorf(`Unexpected bool: got %t, %v`, value, err)
+	}
+	if value, err := parameters.GetFloat("RATIO"); err != nil || value != 0.25 {
+		t.Errorf(`Unexpected float: got %f, %v`, value, err)
+	}
+	if value, err := parameters.GetDuration("TIMEOUT"); err != nil || value != 90*time.Second {
+		t.Errorf(`Unexpected duration: got %s, %v`, value, err)
+	}
+	if value, err := parameters.GetTime("RELEASED"); err != nil || !value.Equal(time.Date(2019, 11, 4, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected time: got %s, %v`, value, err)
+	}
+	if value, err := parameters.GetStringList("HOSTS"); err != nil || !reflect.DeepEqual(value, []string{"a.aws.com", "b.aws.com"}) {
+		t.Errorf(`Unexpected list: got %v, %v`, value, err)
+	}
+	if value, err := parameters.GetStringList("EMPTY"); err != nil || len(value) != 0 {
+		t.Errorf(`Unexpected list: got %v, %v`, value, err)
+	}
+	var limits struct {
+		Max int `json:"max"`
+	}
+	if err := parameters.GetJSON("LIMITS", &limits); err != nil || limits.Max != 10 {
+		t.Errorf(`Unexpected JSON: got %+v, %v`, limits, err)
+	}
+
+	_, err := parameters.GetInt("NOT_EXISTING_PARAMETER")
+	if !errors.Is(err, ErrParameterNotFound) {
+		t.Errorf(`Unexpected error: got %v, expected %v`, err, ErrParameterNotFound)
+	}
+	var formatErr *ParameterFormatError
+	if errors.As(err, &formatErr) {
+		t.Errorf(`Unexpected format error for a missing parameter: %v`, err)
+	}
+
+	_, err = parameters.GetInt("NOT_NUMBER")
+	if !errors.As(err, &formatErr) || formatErr.Name != "/my-service/dev/NOT_NUMBER" {
+		t.Errorf(`Unexpected error: got %v, expected a format error`, err)
+	}
+	if errors.Is(err, ErrParameterNotFound) {
+		t.Errorf(`Unexpected not found error for a bad format: %v`, err)
+	}
+	if strings.Contains(err.Error(), "something-secure") {
+		t.Errorf(`Unexpected value in the error: %v`, err)
+	}
+}
+
 func getParametersMap() map[string]*Parameter {
 	return map[string]*Parameter{
 		"/my-service/dev/DB_PASSWORD": {Value: param1.Value},
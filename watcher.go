@@ -0,0 +1,244 @@
+package awsssm
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWatcherInterval = time.Minute
+	defaultWatcherJitter   = 0.1
+	maxWatcherJitter       = 0.5
+	minWatcherWait         = 100 * time.Millisecond
+)
+
+// WatcherOptions holds the settings of a Watcher
+type WatcherOptions struct {
+	// Decrypt the SecureString parameters
+	Decrypt bool
+	// Recursive also watches the nested paths
+	Recursive bool
+	// Interval between two refreshes, 1 minute is used when empty
+	Interval time.Duration
+	// Jitter is the fraction of the interval that is randomly added or removed to each wait, 0.1 is used when empty
+	// and it is capped at 0.5
+	Jitter float64
+	// MaxBackoff is the longest wait after consecutive failed refreshes, 10 intervals is used when empty
+	MaxBackoff time.Duration
+	// OnError is called with the error of each failed refresh
+	OnError func(error)
+}
+
+// ParametersChange holds the difference between two refreshes of a watched path
+// The keys are relative to the watched path, as returned by Parameters.GetAllValues
+type ParametersChange struct {
+	Parameters *Parameters
+	Added      []string
+	Changed    []string
+	Removed    []string
+	// refresh is the number of the refresh the parameters come from
+	refresh uint64
+}
+
+// Watcher periodically fetches all the parameters of a path and notifies its subscribers when they change
+type Watcher struct {
+	store   *ParameterStore
+	path    string
+	options WatcherOptions
+
+	mu          sync.Mutex
+	parameters  *Parameters
+	subscribers []func(ParametersChange)
+	// refreshes numbers the refreshes in the order they started, and applied is the number of the current parameters
+	refreshes uint64
+	applied   uint64
+	// pending are the changes waiting to be notified, by the refresh that is notifying if there is one
+	pending   []pendingChange
+	notifying bool
+}
+
+// pendingChange is a change with the subscribers registered when it was applied
+type pendingChange struct {
+	change      ParametersChange
+	subscribers []func(ParametersChange)
+}
+
+// NewWatcher is creating a new Watcher of the given path, it starts watching once Run is called
+func NewWatcher(store *ParameterStore, path string, options WatcherOptions) *Watcher {
+	if options.Interval <= 0 {
+		options.Interval = defaultWatcherInterval
+	}
+	if options.Jitter <= 0 {
+		options.Jitter = defaultWatcherJitter
+	}
+	if options.Jitter > maxWatcherJitter {
+		options.Jitter = maxWatcherJitter
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = 10 * options.Interval
+	}
+	return &Watcher{
+		store:   store,
+		path:    path,
+		options: options,
+	}
+}
+
+// Subscribe registers a function that is called with every change of the watched path
+// The first successful refresh reports all the parameters as added
+func (w *Watcher) Subscribe(fn func(ParametersChange)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// subscribeWithSnapshot registers a subscriber and returns the current parameters, or nil, with the number of their refresh
+// The subscriber is notified of every change applied after them, all having a higher refresh number
+func (w *Watcher) subscribeWithSnapshot(fn func(ParametersChange)) (*Parameters, uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+	return w.parameters, w.applied
+}
+
+// Parameters returns the parameters of the last successful refresh, or nil if there was none yet
+func (w *Watcher) Parameters() *Parameters {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.parameters
+}
+
+// Run refreshes the watched path until the context is done
+// The waits between refreshes are jittered and grow exponentially after failed refreshes
+func (w *Watcher) Run(ctx context.Context) {
+	failures := 0
+	for {
+		if err := w.Refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+			if w.options.OnError != nil {
+				w.options.OnError(err)
+			}
+		} else {
+			failures = 0
+		}
+
+		timer := time.NewTimer(w.nextWait(failures))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Refresh fetches the watched path once and notifies the subscribers if anything changed
+// Concurrent refreshes notify their changes one at a time and in order, and the parameters of a refresh
+// that completes after a more recent one are discarded. A subscriber can call Refresh, the change is
+// then notified once the subscriber returned
+func (w *Watcher) Refresh(ctx context.Context) error {
+	w.mu.Lock()
+	w.refreshes++
+	refresh := w.refreshes
+	w.mu.Unlock()
+
+	var parameters *Parameters
+	var err error
+	if w.options.Recursive {
+		parameters, err = w.store.GetAllParametersByPathRecursiveWithContext(ctx, w.path, w.options.Decrypt)
+	} else {
+		parameters, err = w.store.GetAllParametersByPathWithContext(ctx, w.path, w.options.Decrypt)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if refresh < w.applied {
+		return nil
+	}
+	previous := w.parameters
+	w.parameters = parameters
+	w.applied = refresh
+
+	change := diffParameters(previous, parameters)
+	change.refresh = refresh
+	if previous != nil && len(change.Added) == 0 && len(change.Changed) == 0 && len(change.Removed) == 0 {
+		return nil
+	}
+	subscribers := make([]func(ParametersChange), len(w.subscribers))
+	copy(subscribers, w.subscribers)
+	w.pending = append(w.pending, pendingChange{change: change, subscribers: subscribers})
+	if !w.notifying {
+		w.notify()
+	}
+	return nil
+}
+
+// notify calls the subscribers with the pending changes until there are none left, it is called with w.mu held
+// and releases it while the subscribers are called so they can use the Watcher
+func (w *Watcher) notify() {
+	w.notifying = true
+	defer func() { w.notifying = false }()
+	for len(w.pending) > 0 {
+		next := w.pending[0]
+		w.pending = w.pending[1:]
+		w.mu.Unlock()
+		func() {
+			defer w.mu.Lock()
+			for _, subscriber := range next.subscribers {
+				subscriber(next.change)
+			}
+		}()
+	}
+}
+
+func (w *Watcher) nextWait(failures int) time.Duration {
+	wait := w.options.Interval
+	for i := 0; i < failures && wait < w.options.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > w.options.MaxBackoff {
+		wait = w.options.MaxBackoff
+	}
+	// #nosec G404 -- the jitter doesn't need a cryptographically secure random number
+	jitter := (rand.Float64()*2 - 1) * w.options.Jitter * float64(wait)
+	wait += time.Duration(jitter)
+	if wait < minWatcherWait {
+		wait = minWatcherWait
+	}
+	return wait
+}
+
+func diffParameters(previous, current *Parameters) ParametersChange {
+	change := ParametersChange{Parameters: current}
+	var previousValues map[string]string
+	if previous != nil {
+		previousValues = previous.getKeyValueMap()
+	}
+	currentValues := current.getKeyValueMap()
+	for key, value := range currentValues {
+		previousValue, ok := previousValues[key]
+		if !ok {
+			change.Added = append(change.Added, key)
+		} else if previousValue != value {
+			change.Changed = append(change.Changed, key)
+		}
+	}
+	for key := range previousValues {
+		if _, ok := currentValues[key]; !ok {
+			change.Removed = append(change.Removed, key)
+		}
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Changed)
+	sort.Strings(change.Removed)
+	return change
+}
@@ -0,0 +1,275 @@
+package awsssm
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+func stubPathOutput(parameters ...*ssm.Parameter) []stubGetParametersByPathOutput {
+	return []stubGetParametersByPathOutput{
+		{Output: ssm.GetParametersByPathOutput{Parameters: parameters}},
+	}
+}
+
+func TestWatcher_Refresh(t *testing.T) {
+	changedHost := new(ssm.Parameter).
+		SetName("/my-service/dev/DB_HOST").
+		SetValue("rds.something-else.aws.com")
+
+	stub := &stubSSMClient{GetParametersByPathOutput: stubPathOutput(param1, param2)}
+	watcher := NewWatcher(NewParameterStoreWithClient(stub), "/my-service/dev/", WatcherOptions{})
+	var changes []ParametersChange
+	watcher.Subscribe(func(change ParametersChange) {
+		changes = append(changes, ParametersChange{Added: change.Added, Changed: change.Changed, Removed: change.Removed})
+	})
+
+	refreshes := []struct {
+		name           string
+		output         []stubGetParametersByPathOutput
+		expectedChange *ParametersChange
+	}{
+		{
+			name:           "Initial Refresh",
+			output:         stubPathOutput(param1, param2),
+			expectedChange: &ParametersChange{Added: []string{"DB_HOST", "DB_PASSWORD"}},
+		},
+		{
+			name:   "Nothing Changed",
+			output: stubPathOutput(param2, param1),
+		},
+		{
+			name:   "Added Changed And Removed",
+			output: stubPathOutput(changedHost, param3),
+			expectedChange: &ParametersChange{
+				Added:   []string{"DB_USERNAME"},
+				Changed: []string{"DB_HOST"},
+				Removed: []string{"DB_PASSWORD"},
+			},
+		},
+	}
+	for _, refresh := range refreshes {
+		t.Run(refresh.name, func(t *testing.T) {
+			changes = nil
+			stub.GetParametersByPathOutput = refresh.output
+			if err := watcher.Refresh(context.Background()); err != nil {
+				t.Fatalf(`Unexpected error: %s`, err)
+			}
+			if refresh.expectedChange == nil {
+				if len(changes) != 0 {
+					t.Errorf(`Unexpected changes: %+v`, changes)
+				}
+				return
+			}
+			if len(changes) != 1 || !reflect.DeepEqual(changes[0], *refresh.expectedChange) {
+				t.Errorf(`Unexpected changes: got %+v, expected %+v`, changes, *refresh.expectedChange)
+			}
+		})
+	}
+	if value := watcher.Parameters().GetValueByName("DB_HOST"); value != "rds.something-else.aws.com" {
+		t.Errorf(`Unexpected value: got %s, expected %s`, value, "rds.something-else.aws.com")
+	}
+}
+
+// blockingPathSSMClient serves the given parameters by path, the requests made while block is set wait until it is closed
+type blockingPathSSMClient struct {
+	stubSSMClient
+	mu         sync.Mutex
+	block      chan struct{}
+	blocked    int
+	parameters []*ssm.Parameter
+}
+
+func (s *blockingPathSSMClient) GetParametersByPathPagesWithContext(ctx aws.Context, input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool, opts ...request.Option) error {
+	s.mu.Lock()
+	block, parameters := s.block, s.parameters
+	if block != nil {
+		s.blocked++
+	}
+	s.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+	fn(&ssm.GetParametersByPathOutput{Parameters: parameters}, true)
+	return nil
+}
+
+func (s *blockingPathSSMClient) serve(block chan struct{}, parameters ...*ssm.Parameter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.block, s.parameters = block, parameters
+}
+
+func TestWatcher_RefreshHanging(t *testing.T) {
+	changedHost := new(ssm.Parameter).
+		SetName("/my-service/dev/DB_HOST").
+		SetValue("rds.something-else.aws.com")
+	client := &blockingPathSSMClient{}
+	watcher := NewWatcher(NewParameterStoreWithClient(client), "/my-service/dev/", WatcherOptions{})
+
+	release := make(chan struct{})
+	client.serve(release, param2)
+	hanging := make(chan error)
+	go func() {
+		hanging <- watcher.Refresh(context.Background())
+	}()
+	for {
+		client.mu.Lock()
+		blocked := client.blocked
+		client.mu.Unlock()
+		if blocked == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	client.serve(nil, changedHost)
+	if err := watcher.Refresh(context.Background()); err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if value := NewLive[liveEnv](watcher, nil).Load(); value == nil || value.DatabaseHost != "rds.something-else.aws.com" {
+		t.Errorf(`Unexpected value while a refresh hangs: %+v`, value)
+	}
+
+	close(release)
+	if err := <-hanging; err != nil {
+		t.Fatalf(`Unexpected error: %s`, err)
+	}
+	if value := watcher.Parameters().GetValueByName("DB_HOST"); value != "rds.something-else.aws.com" {
+		t.Errorf(`Unexpected value after the older refresh completed: got %s, expected %s`, value, "rds.something-else.aws.com")
+	}
+}
+
+func TestWatcher_RefreshFromSubscriber(t *testing.T) {
+	changedHost := new(ssm.Parameter).
+		SetName("/my-service/dev/DB_HOST").
+		SetValue("rds.something-else.aws.com")
+	client := &blockingPathSSMClient{}
+	client.serve(nil, param2)
+	watcher := NewWatcher(NewParameterStoreWithClient(client), "/my-service/dev/", WatcherOptions{})
+
+	var changes []ParametersChange
+	var live *Live[liveEnv]
+	watcher.Subscribe(func(change ParametersChange) {
+		changes = append(changes, change)
+		if len(changes) == 1 {
+			live = NewLive[liveEnv](watcher, nil)
+			client.serve(nil, changedHost)
+			if err := watcher.Refresh(context.Background()); err != nil {
+				t.Errorf(`Unexpected error: %s`, err)
+			}
+			if len(changes) != 1 {
+				t.Error(`Expected the change of a refresh made by a subscriber to be notified once it returned`)
+			}
+		}
+	})
+	done := make(chan error)
+	go func() {
+		done <- watcher.Refresh(context.Background())
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf(`Unexpected error: %s`, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal(`Refresh didn't return when called from a subscriber`)
+	}
+	if len(changes) != 2 || !reflect.DeepEqual(changes[1].Changed, []string{"DB_HOST"}) {
+		t.Errorf(`Unexpected changes: %+v`, changes)
+	}
+	if value := live.Load(); value == nil || value.DatabaseHost != "rds.something-else.aws.com" {
+		t.Errorf(`Unexpected value of a Live created by a subscriber: %+v`, value)
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	stub := &stubSSMClient{GetParametersByPathError: errSSM}
+	var mu sync.Mutex
+	var errs []error
+	watcher := NewWatcher(NewParameterStoreWithClient(stub), "/my-service/dev/", WatcherOptions{
+		Interval:   time.Millisecond,
+		MaxBackoff: 4 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watcher.Run(ctx)
+		close(done)
+	}()
+	for {
+		mu.Lock()
+		failures := len(errs)
+		mu.Unlock()
+		if failures >= 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal(`Run didn't stop after the context was cancelled`)
+	}
+	if errs[0] != errSSM {
+		t.Errorf(`Unexpected error: got %v, expected %v`, errs[0], errSSM)
+	}
+}
+
+func TestWatcher_nextWait(t *testing.T) {
+	watcher := NewWatcher(nil, "/my-service/dev/", WatcherOptions{
+		Interval:   time.Second,
+		Jitter:     0.1,
+		MaxBackoff: 8 * time.Second,
+	})
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 0, expected: time.Second},
+		{failures: 1, expected: 2 * time.Second},
+		{failures: 2, expected: 4 * time.Second},
+		{failures: 10, expected: 8 * time.Second},
+	}
+	for _, test := range tests {
+		wait := watcher.nextWait(test.failures)
+		low := time.Duration(float64(test.expected) * 0.9)
+		high := time.Duration(float64(test.expected) * 1.1)
+		if wait < low || wait > high {
+			t.Errorf(`Unexpected wait after %d failures: got %s, expected %s ± 10%%`, test.failures, wait, test.expected)
+		}
+	}
+}
+
+func TestWatcher_nextWaitBounds(t *testing.T) {
+	watcher := NewWatcher(nil, "/my-service/dev/", WatcherOptions{
+		Interval: time.Second,
+		Jitter:   1,
+	})
+	if watcher.options.Jitter != maxWatcherJitter {
+		t.Errorf(`Unexpected jitter: got %v, expected %v`, watcher.options.Jitter, maxWatcherJitter)
+	}
+	for i := 0; i < 100; i++ {
+		if wait := watcher.nextWait(0); wait < time.Second/2 {
+			t.Fatalf(`Unexpected wait: got %s, expected at least %s`, wait, time.Second/2)
+		}
+	}
+
+	watcher = NewWatcher(nil, "/my-service/dev/", WatcherOptions{Interval: time.Nanosecond})
+	if wait := watcher.nextWait(0); wait != minWatcherWait {
+		t.Errorf(`Unexpected wait: got %s, expected %s`, wait, minWatcherWait)
+	}
+}